@@ -18,13 +18,26 @@ package main
 
 import (
 	"context"
+	"log"
 
 	"github.com/imjasonh/seccomp-profile/pkg/reconciler/seccompprofile"
+	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	"knative.dev/pkg/injection/sharedmain"
 )
 
 func main() {
-	sharedmain.MainWithContext(sharedmain.WithHADisabled(context.Background()), "controller",
-		seccompprofile.NewController,
+	ctx := sharedmain.WithHADisabled(context.Background())
+	// Profiles are summarized over the nodes the controller runs on.
+	ctx = filteredinformerfactory.WithSelectors(ctx, seccompprofile.AgentSelector)
+
+	// Both controllers write profiles to the node with the same Writer, so
+	// that the node's files are only watched for drift once.
+	w, err := seccompprofile.NewWriter(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sharedmain.MainWithContext(ctx, "controller",
+		seccompprofile.NewController(w),
+		seccompprofile.NewNamespacedController(w),
 	)
 }
@@ -22,12 +22,14 @@ import (
 	"knative.dev/hack/schema/commands"
 	"knative.dev/hack/schema/registry"
 
-	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1alpha1"
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
 )
 
 // schema is a tool to dump the schema for Eventing resources.
 func main() {
-	registry.Register(&v1alpha1.SeccompProfile{})
+	registry.Register(&v1beta1.SeccompProfile{})
+	registry.Register(&v1beta1.NamespacedSeccompProfile{})
+	registry.Register(&v1beta1.SeccompProfileNodeStatus{})
 
 	if err := commands.New("github.com/imjasonh/seccomp-profile").Execute(); err != nil {
 		log.Fatal("Error during command execution: ", err)
@@ -37,16 +37,22 @@ import (
 	"knative.dev/pkg/webhook/certificates"
 	"knative.dev/pkg/webhook/configmaps"
 	"knative.dev/pkg/webhook/resourcesemantics"
+	"knative.dev/pkg/webhook/resourcesemantics/conversion"
 	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
 	"knative.dev/pkg/webhook/resourcesemantics/validation"
 
 	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1alpha1"
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
 	pwebhook "github.com/imjasonh/seccomp-profile/pkg/webhook"
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// List the types to validate.
 	v1alpha1.SchemeGroupVersion.WithKind("SeccompProfile"): &v1alpha1.SeccompProfile{},
+	v1beta1.SchemeGroupVersion.WithKind("SeccompProfile"):  &v1beta1.SeccompProfile{},
+
+	v1beta1.SchemeGroupVersion.WithKind("NamespacedSeccompProfile"): &v1beta1.NamespacedSeccompProfile{},
 }
 
 var callbacks = map[schema.GroupVersionKind]validation.Callback{}
@@ -115,6 +121,32 @@ func NewConfigValidationController(ctx context.Context, cmw configmap.Watcher) *
 		configmap.Constructors{
 			logging.ConfigMapName(): logging.NewConfigFromConfigMap,
 			metrics.ConfigMapName(): metrics.NewObservabilityConfigFromConfigMap,
+			config.ConfigMapName:    config.NewConfigFromConfigMap,
+		},
+	)
+}
+
+func NewConversionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return conversion.NewConversionController(ctx,
+
+		// The path on which to serve the webhook.
+		"/resource-conversion",
+
+		// Specify the types of custom resource definitions that should be converted.
+		map[schema.GroupKind]conversion.GroupKindConversion{
+			v1beta1.Kind("SeccompProfile"): {
+				DefinitionName: "seccompprofiles.seccomp.imjasonh.dev",
+				HubVersion:     v1alpha1.SchemeGroupVersion.Version,
+				Zygotes: map[string]conversion.ConvertibleObject{
+					v1alpha1.SchemeGroupVersion.Version: &v1alpha1.SeccompProfile{},
+					v1beta1.SchemeGroupVersion.Version:  &v1beta1.SeccompProfile{},
+				},
+			},
+		},
+
+		// A function that infuses the context passed to ConvertTo/ConvertFrom/SetDefaults with custom metadata.
+		func(ctx context.Context) context.Context {
+			return ctx
 		},
 	)
 }
@@ -162,6 +194,10 @@ func NewMutatingAdmissionController(ctx context.Context, cmw configmap.Watcher)
 	kc := kubeclient.Get(ctx)
 	validator := pwebhook.NewValidator(ctx)
 
+	// Decorate contexts with the current state of the config.
+	store := config.NewStore(logging.FromContext(ctx).Named("config-store"))
+	store.WatchConfigs(cmw)
+
 	return defaulting.NewAdmissionController(ctx,
 		// Name of the resource webhook.
 		"mutating.seccomp.imjasonh.dev",
@@ -184,6 +220,7 @@ func NewMutatingAdmissionController(ctx context.Context, cmw configmap.Watcher)
 		// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 		func(ctx context.Context) context.Context {
 			ctx = context.WithValue(ctx, kubeclient.Key{}, kc)
+			ctx = store.ToContext(ctx)
 			ctx = duckv1.WithPodDefaulter(ctx, validator.ResolvePod)
 			ctx = duckv1.WithPodSpecDefaulter(ctx, validator.ResolvePodSpecable)
 			ctx = duckv1.WithCronJobDefaulter(ctx, validator.ResolveCronJob)
@@ -209,5 +246,6 @@ func main() {
 		NewValidationAdmissionController,
 		NewConfigValidationController,
 		NewMutatingAdmissionController,
+		NewConversionController,
 	)
 }
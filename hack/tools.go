@@ -34,4 +34,7 @@ import (
 	_ "k8s.io/code-generator/cmd/lister-gen"
 	_ "k8s.io/kube-openapi/cmd/openapi-gen"
 	_ "knative.dev/pkg/codegen/cmd/injection-gen"
+
+	// For migrating stored SeccompProfiles to the storage version.
+	_ "knative.dev/pkg/apiextensions/storageversion/cmd/migrate"
 )
@@ -35,7 +35,7 @@ group "Kubernetes Codegen"
 #                  instead of the $GOPATH directly. For normal projects this can be dropped.
 ${CODEGEN_PKG}/generate-groups.sh "deepcopy,client,informer,lister" \
   github.com/imjasonh/seccomp-profile/pkg/apis github.com/imjasonh/seccomp-profile/pkg/apis\
-  "seccomp:v1alpha1" \
+  "seccomp:v1alpha1,v1beta1" \
   --go-header-file ${REPO_ROOT_DIR}/hack/boilerplate/boilerplate.go.txt
 
 group "Knative Codegen"
@@ -43,15 +43,23 @@ group "Knative Codegen"
 # Knative Injection
 ${KNATIVE_CODEGEN_PKG}/hack/generate-knative.sh "injection" \
   github.com/imjasonh/seccomp-profile/pkg/apis github.com/imjasonh/seccomp-profile/pkg/apis \
-  "seccomp:v1alpha1" \
+  "seccomp:v1alpha1,v1beta1" \
   --go-header-file ${REPO_ROOT_DIR}/hack/boilerplate/boilerplate.go.txt
 
 group "Update CRD Schema"
 
 go run $(dirname $0)/../cmd/schema/ dump SeccompProfile \
-  | run_yq eval-all --header-preprocess=false --inplace 'select(fileIndex == 0).spec.versions[0].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
+  | run_yq eval-all --header-preprocess=false --inplace 'select(fileIndex == 0).spec.versions[1].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
   $(dirname $0)/../config/300-seccompprofile.yaml -
 
+go run $(dirname $0)/../cmd/schema/ dump NamespacedSeccompProfile \
+  | run_yq eval-all --header-preprocess=false --inplace 'select(fileIndex == 0).spec.versions[0].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
+  $(dirname $0)/../config/301-namespacedseccompprofile.yaml -
+
+go run $(dirname $0)/../cmd/schema/ dump SeccompProfileNodeStatus \
+  | run_yq eval-all --header-preprocess=false --inplace 'select(fileIndex == 0).spec.versions[0].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
+  $(dirname $0)/../config/302-seccompprofilenodestatus.yaml -
+
 group "Update deps post-codegen"
 
 # Make sure our dependencies are up-to-date
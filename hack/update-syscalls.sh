@@ -0,0 +1,47 @@
+#!/usr/bin/env bash
+
+# Copyright 2019 The Knative Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Regenerates the syscall tables in pkg/syscalls/tables from the latest
+# golang.org/x/sys, which generates its own from the kernel headers.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+REPO_ROOT_DIR=$(dirname $0)/..
+TABLES=${REPO_ROOT_DIR}/pkg/syscalls/tables
+SYS_DIR=$(cd "$(mktemp -d)" && GOFLAGS= go mod download -json golang.org/x/sys@latest | sed -n 's/.*"Dir": "\(.*\)",/\1/p')
+
+# table <GOARCH> <file> [extra syscalls...]
+function table() {
+  sed -n 's/^\tSYS_\([A-Z0-9_]*\) *= [0-9].*/\1/p' "${SYS_DIR}/unix/zsysnum_linux_$1.go" \
+    | tr '[:upper:]' '[:lower:]' | grep -v '^arch_specific_syscall$' \
+    | cat - <(printf '%s\n' "${@:3}") | grep -v '^$' | LC_ALL=C sort -u > "${TABLES}/$2.txt"
+}
+
+mkdir -p "${TABLES}"
+table amd64 x86_64
+table 386 x86
+table arm64 aarch64
+# libseccomp also names the ARM private syscalls and sync_file_range2 (an
+# alias of arm_sync_file_range), which x/sys doesn't list.
+table arm arm breakpoint cacheflush set_tls usr26 usr32 sync_file_range2
+table riscv64 riscv64
+table s390x s390x
+table ppc64le ppc64le
+
+# x32 uses the x86_64 syscall table.
+cp "${TABLES}/x86_64.txt" "${TABLES}/x32.txt"
@@ -23,6 +23,7 @@ import (
 	"net/http"
 
 	seccompv1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1alpha1"
+	seccompv1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1beta1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -31,6 +32,7 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	SeccompV1alpha1() seccompv1alpha1.SeccompV1alpha1Interface
+	SeccompV1beta1() seccompv1beta1.SeccompV1beta1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
@@ -38,6 +40,7 @@ type Interface interface {
 type Clientset struct {
 	*discovery.DiscoveryClient
 	seccompV1alpha1 *seccompv1alpha1.SeccompV1alpha1Client
+	seccompV1beta1  *seccompv1beta1.SeccompV1beta1Client
 }
 
 // SeccompV1alpha1 retrieves the SeccompV1alpha1Client
@@ -45,6 +48,11 @@ func (c *Clientset) SeccompV1alpha1() seccompv1alpha1.SeccompV1alpha1Interface {
 	return c.seccompV1alpha1
 }
 
+// SeccompV1beta1 retrieves the SeccompV1beta1Client
+func (c *Clientset) SeccompV1beta1() seccompv1beta1.SeccompV1beta1Interface {
+	return c.seccompV1beta1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -93,6 +101,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.seccompV1beta1, err = seccompv1beta1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -115,6 +127,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.seccompV1alpha1 = seccompv1alpha1.New(c)
+	cs.seccompV1beta1 = seccompv1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
@@ -22,6 +22,8 @@ import (
 	clientset "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned"
 	seccompv1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1alpha1"
 	fakeseccompv1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1alpha1/fake"
+	seccompv1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1beta1"
+	fakeseccompv1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1beta1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -83,3 +85,8 @@ var (
 func (c *Clientset) SeccompV1alpha1() seccompv1alpha1.SeccompV1alpha1Interface {
 	return &fakeseccompv1alpha1.FakeSeccompV1alpha1{Fake: &c.Fake}
 }
+
+// SeccompV1beta1 retrieves the SeccompV1beta1Client
+func (c *Clientset) SeccompV1beta1() seccompv1beta1.SeccompV1beta1Interface {
+	return &fakeseccompv1beta1.FakeSeccompV1beta1{Fake: &c.Fake}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeNamespacedSeccompProfiles implements NamespacedSeccompProfileInterface
+type FakeNamespacedSeccompProfiles struct {
+	Fake *FakeSeccompV1beta1
+	ns   string
+}
+
+var namespacedseccompprofilesResource = schema.GroupVersionResource{Group: "seccomp.imjasonh.dev", Version: "v1beta1", Resource: "namespacedseccompprofiles"}
+
+var namespacedseccompprofilesKind = schema.GroupVersionKind{Group: "seccomp.imjasonh.dev", Version: "v1beta1", Kind: "NamespacedSeccompProfile"}
+
+// Get takes name of the namespacedSeccompProfile, and returns the corresponding namespacedSeccompProfile object, and an error if there is any.
+func (c *FakeNamespacedSeccompProfiles) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(namespacedseccompprofilesResource, c.ns, name), &v1beta1.NamespacedSeccompProfile{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.NamespacedSeccompProfile), err
+}
+
+// List takes label and field selectors, and returns the list of NamespacedSeccompProfiles that match those selectors.
+func (c *FakeNamespacedSeccompProfiles) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.NamespacedSeccompProfileList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(namespacedseccompprofilesResource, namespacedseccompprofilesKind, c.ns, opts), &v1beta1.NamespacedSeccompProfileList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.NamespacedSeccompProfileList{ListMeta: obj.(*v1beta1.NamespacedSeccompProfileList).ListMeta}
+	for _, item := range obj.(*v1beta1.NamespacedSeccompProfileList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested namespacedSeccompProfiles.
+func (c *FakeNamespacedSeccompProfiles) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(namespacedseccompprofilesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a namespacedSeccompProfile and creates it.  Returns the server's representation of the namespacedSeccompProfile, and an error, if there is any.
+func (c *FakeNamespacedSeccompProfiles) Create(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.CreateOptions) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(namespacedseccompprofilesResource, c.ns, namespacedSeccompProfile), &v1beta1.NamespacedSeccompProfile{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.NamespacedSeccompProfile), err
+}
+
+// Update takes the representation of a namespacedSeccompProfile and updates it. Returns the server's representation of the namespacedSeccompProfile, and an error, if there is any.
+func (c *FakeNamespacedSeccompProfiles) Update(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(namespacedseccompprofilesResource, c.ns, namespacedSeccompProfile), &v1beta1.NamespacedSeccompProfile{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.NamespacedSeccompProfile), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeNamespacedSeccompProfiles) UpdateStatus(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (*v1beta1.NamespacedSeccompProfile, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(namespacedseccompprofilesResource, "status", c.ns, namespacedSeccompProfile), &v1beta1.NamespacedSeccompProfile{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.NamespacedSeccompProfile), err
+}
+
+// Delete takes name of the namespacedSeccompProfile and deletes it. Returns an error if one occurs.
+func (c *FakeNamespacedSeccompProfiles) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(namespacedseccompprofilesResource, c.ns, name, opts), &v1beta1.NamespacedSeccompProfile{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeNamespacedSeccompProfiles) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(namespacedseccompprofilesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.NamespacedSeccompProfileList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched namespacedSeccompProfile.
+func (c *FakeNamespacedSeccompProfiles) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(namespacedseccompprofilesResource, c.ns, name, pt, data, subresources...), &v1beta1.NamespacedSeccompProfile{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.NamespacedSeccompProfile), err
+}
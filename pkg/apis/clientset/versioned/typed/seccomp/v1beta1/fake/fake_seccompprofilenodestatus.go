@@ -0,0 +1,130 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSeccompProfileNodeStatuses implements SeccompProfileNodeStatusInterface
+type FakeSeccompProfileNodeStatuses struct {
+	Fake *FakeSeccompV1beta1
+	ns   string
+}
+
+var seccompprofilenodestatusesResource = schema.GroupVersionResource{Group: "seccomp.imjasonh.dev", Version: "v1beta1", Resource: "seccompprofilenodestatuses"}
+
+var seccompprofilenodestatusesKind = schema.GroupVersionKind{Group: "seccomp.imjasonh.dev", Version: "v1beta1", Kind: "SeccompProfileNodeStatus"}
+
+// Get takes name of the seccompProfileNodeStatus, and returns the corresponding seccompProfileNodeStatus object, and an error if there is any.
+func (c *FakeSeccompProfileNodeStatuses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(seccompprofilenodestatusesResource, c.ns, name), &v1beta1.SeccompProfileNodeStatus{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.SeccompProfileNodeStatus), err
+}
+
+// List takes label and field selectors, and returns the list of SeccompProfileNodeStatuses that match those selectors.
+func (c *FakeSeccompProfileNodeStatuses) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.SeccompProfileNodeStatusList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(seccompprofilenodestatusesResource, seccompprofilenodestatusesKind, c.ns, opts), &v1beta1.SeccompProfileNodeStatusList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.SeccompProfileNodeStatusList{ListMeta: obj.(*v1beta1.SeccompProfileNodeStatusList).ListMeta}
+	for _, item := range obj.(*v1beta1.SeccompProfileNodeStatusList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested seccompProfileNodeStatuses.
+func (c *FakeSeccompProfileNodeStatuses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(seccompprofilenodestatusesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a seccompProfileNodeStatus and creates it.  Returns the server's representation of the seccompProfileNodeStatus, and an error, if there is any.
+func (c *FakeSeccompProfileNodeStatuses) Create(ctx context.Context, seccompProfileNodeStatus *v1beta1.SeccompProfileNodeStatus, opts v1.CreateOptions) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(seccompprofilenodestatusesResource, c.ns, seccompProfileNodeStatus), &v1beta1.SeccompProfileNodeStatus{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.SeccompProfileNodeStatus), err
+}
+
+// Update takes the representation of a seccompProfileNodeStatus and updates it. Returns the server's representation of the seccompProfileNodeStatus, and an error, if there is any.
+func (c *FakeSeccompProfileNodeStatuses) Update(ctx context.Context, seccompProfileNodeStatus *v1beta1.SeccompProfileNodeStatus, opts v1.UpdateOptions) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(seccompprofilenodestatusesResource, c.ns, seccompProfileNodeStatus), &v1beta1.SeccompProfileNodeStatus{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.SeccompProfileNodeStatus), err
+}
+
+// Delete takes name of the seccompProfileNodeStatus and deletes it. Returns an error if one occurs.
+func (c *FakeSeccompProfileNodeStatuses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(seccompprofilenodestatusesResource, c.ns, name, opts), &v1beta1.SeccompProfileNodeStatus{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSeccompProfileNodeStatuses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(seccompprofilenodestatusesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.SeccompProfileNodeStatusList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched seccompProfileNodeStatus.
+func (c *FakeSeccompProfileNodeStatuses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(seccompprofilenodestatusesResource, c.ns, name, pt, data, subresources...), &v1beta1.SeccompProfileNodeStatus{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.SeccompProfileNodeStatus), err
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	scheme "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/scheme"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// NamespacedSeccompProfilesGetter has a method to return a NamespacedSeccompProfileInterface.
+// A group's client should implement this interface.
+type NamespacedSeccompProfilesGetter interface {
+	NamespacedSeccompProfiles(namespace string) NamespacedSeccompProfileInterface
+}
+
+// NamespacedSeccompProfileInterface has methods to work with NamespacedSeccompProfile resources.
+type NamespacedSeccompProfileInterface interface {
+	Create(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.CreateOptions) (*v1beta1.NamespacedSeccompProfile, error)
+	Update(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (*v1beta1.NamespacedSeccompProfile, error)
+	UpdateStatus(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (*v1beta1.NamespacedSeccompProfile, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.NamespacedSeccompProfile, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.NamespacedSeccompProfileList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.NamespacedSeccompProfile, err error)
+	NamespacedSeccompProfileExpansion
+}
+
+// namespacedSeccompProfiles implements NamespacedSeccompProfileInterface
+type namespacedSeccompProfiles struct {
+	client rest.Interface
+	ns     string
+}
+
+// newNamespacedSeccompProfiles returns a NamespacedSeccompProfiles
+func newNamespacedSeccompProfiles(c *SeccompV1beta1Client, namespace string) *namespacedSeccompProfiles {
+	return &namespacedSeccompProfiles{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the namespacedSeccompProfile, and returns the corresponding namespacedSeccompProfile object, and an error if there is any.
+func (c *namespacedSeccompProfiles) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	result = &v1beta1.NamespacedSeccompProfile{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of NamespacedSeccompProfiles that match those selectors.
+func (c *namespacedSeccompProfiles) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.NamespacedSeccompProfileList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.NamespacedSeccompProfileList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested namespacedSeccompProfiles.
+func (c *namespacedSeccompProfiles) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a namespacedSeccompProfile and creates it.  Returns the server's representation of the namespacedSeccompProfile, and an error, if there is any.
+func (c *namespacedSeccompProfiles) Create(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.CreateOptions) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	result = &v1beta1.NamespacedSeccompProfile{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespacedSeccompProfile).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a namespacedSeccompProfile and updates it. Returns the server's representation of the namespacedSeccompProfile, and an error, if there is any.
+func (c *namespacedSeccompProfiles) Update(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	result = &v1beta1.NamespacedSeccompProfile{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		Name(namespacedSeccompProfile.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespacedSeccompProfile).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *namespacedSeccompProfiles) UpdateStatus(ctx context.Context, namespacedSeccompProfile *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	result = &v1beta1.NamespacedSeccompProfile{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		Name(namespacedSeccompProfile.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(namespacedSeccompProfile).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the namespacedSeccompProfile and deletes it. Returns an error if one occurs.
+func (c *namespacedSeccompProfiles) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *namespacedSeccompProfiles) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched namespacedSeccompProfile.
+func (c *namespacedSeccompProfiles) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	result = &v1beta1.NamespacedSeccompProfile{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("namespacedseccompprofiles").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
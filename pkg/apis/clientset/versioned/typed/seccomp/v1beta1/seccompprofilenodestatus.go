@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	"time"
+
+	scheme "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/scheme"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// SeccompProfileNodeStatusesGetter has a method to return a SeccompProfileNodeStatusInterface.
+// A group's client should implement this interface.
+type SeccompProfileNodeStatusesGetter interface {
+	SeccompProfileNodeStatuses(namespace string) SeccompProfileNodeStatusInterface
+}
+
+// SeccompProfileNodeStatusInterface has methods to work with SeccompProfileNodeStatus resources.
+type SeccompProfileNodeStatusInterface interface {
+	Create(ctx context.Context, seccompProfileNodeStatus *v1beta1.SeccompProfileNodeStatus, opts v1.CreateOptions) (*v1beta1.SeccompProfileNodeStatus, error)
+	Update(ctx context.Context, seccompProfileNodeStatus *v1beta1.SeccompProfileNodeStatus, opts v1.UpdateOptions) (*v1beta1.SeccompProfileNodeStatus, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.SeccompProfileNodeStatus, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.SeccompProfileNodeStatusList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.SeccompProfileNodeStatus, err error)
+	SeccompProfileNodeStatusExpansion
+}
+
+// seccompProfileNodeStatuses implements SeccompProfileNodeStatusInterface
+type seccompProfileNodeStatuses struct {
+	client rest.Interface
+	ns     string
+}
+
+// newSeccompProfileNodeStatuses returns a SeccompProfileNodeStatuses
+func newSeccompProfileNodeStatuses(c *SeccompV1beta1Client, namespace string) *seccompProfileNodeStatuses {
+	return &seccompProfileNodeStatuses{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the seccompProfileNodeStatus, and returns the corresponding seccompProfileNodeStatus object, and an error if there is any.
+func (c *seccompProfileNodeStatuses) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	result = &v1beta1.SeccompProfileNodeStatus{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of SeccompProfileNodeStatuses that match those selectors.
+func (c *seccompProfileNodeStatuses) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.SeccompProfileNodeStatusList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.SeccompProfileNodeStatusList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested seccompProfileNodeStatuses.
+func (c *seccompProfileNodeStatuses) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a seccompProfileNodeStatus and creates it.  Returns the server's representation of the seccompProfileNodeStatus, and an error, if there is any.
+func (c *seccompProfileNodeStatuses) Create(ctx context.Context, seccompProfileNodeStatus *v1beta1.SeccompProfileNodeStatus, opts v1.CreateOptions) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	result = &v1beta1.SeccompProfileNodeStatus{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(seccompProfileNodeStatus).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a seccompProfileNodeStatus and updates it. Returns the server's representation of the seccompProfileNodeStatus, and an error, if there is any.
+func (c *seccompProfileNodeStatuses) Update(ctx context.Context, seccompProfileNodeStatus *v1beta1.SeccompProfileNodeStatus, opts v1.UpdateOptions) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	result = &v1beta1.SeccompProfileNodeStatus{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		Name(seccompProfileNodeStatus.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(seccompProfileNodeStatus).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the seccompProfileNodeStatus and deletes it. Returns an error if one occurs.
+func (c *seccompProfileNodeStatuses) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *seccompProfileNodeStatuses) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched seccompProfileNodeStatus.
+func (c *seccompProfileNodeStatuses) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	result = &v1beta1.SeccompProfileNodeStatus{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("seccompprofilenodestatuses").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	v1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1alpha1"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -56,6 +57,14 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1alpha1.SchemeGroupVersion.WithResource("seccompprofiles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Seccomp().V1alpha1().SeccompProfiles().Informer()}, nil
 
+		// Group=seccomp.imjasonh.dev, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("namespacedseccompprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Seccomp().V1beta1().NamespacedSeccompProfiles().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("seccompprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Seccomp().V1beta1().SeccompProfiles().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("seccompprofilenodestatuses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Seccomp().V1beta1().SeccompProfileNodeStatuses().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
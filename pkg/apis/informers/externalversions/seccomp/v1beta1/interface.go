@@ -0,0 +1,59 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	internalinterfaces "github.com/imjasonh/seccomp-profile/pkg/apis/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// NamespacedSeccompProfiles returns a NamespacedSeccompProfileInformer.
+	NamespacedSeccompProfiles() NamespacedSeccompProfileInformer
+	// SeccompProfiles returns a SeccompProfileInformer.
+	SeccompProfiles() SeccompProfileInformer
+	// SeccompProfileNodeStatuses returns a SeccompProfileNodeStatusInformer.
+	SeccompProfileNodeStatuses() SeccompProfileNodeStatusInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// NamespacedSeccompProfiles returns a NamespacedSeccompProfileInformer.
+func (v *version) NamespacedSeccompProfiles() NamespacedSeccompProfileInformer {
+	return &namespacedSeccompProfileInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SeccompProfiles returns a SeccompProfileInformer.
+func (v *version) SeccompProfiles() SeccompProfileInformer {
+	return &seccompProfileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// SeccompProfileNodeStatuses returns a SeccompProfileNodeStatusInformer.
+func (v *version) SeccompProfileNodeStatuses() SeccompProfileNodeStatusInformer {
+	return &seccompProfileNodeStatusInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
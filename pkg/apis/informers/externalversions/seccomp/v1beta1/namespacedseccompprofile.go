@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	time "time"
+
+	versioned "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned"
+	internalinterfaces "github.com/imjasonh/seccomp-profile/pkg/apis/informers/externalversions/internalinterfaces"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	seccompv1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// NamespacedSeccompProfileInformer provides access to a shared informer and lister for
+// NamespacedSeccompProfiles.
+type NamespacedSeccompProfileInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1beta1.NamespacedSeccompProfileLister
+}
+
+type namespacedSeccompProfileInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewNamespacedSeccompProfileInformer constructs a new informer for NamespacedSeccompProfile type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewNamespacedSeccompProfileInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredNamespacedSeccompProfileInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredNamespacedSeccompProfileInformer constructs a new informer for NamespacedSeccompProfile type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredNamespacedSeccompProfileInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SeccompV1beta1().NamespacedSeccompProfiles(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SeccompV1beta1().NamespacedSeccompProfiles(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&seccompv1beta1.NamespacedSeccompProfile{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *namespacedSeccompProfileInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredNamespacedSeccompProfileInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *namespacedSeccompProfileInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&seccompv1beta1.NamespacedSeccompProfile{}, f.defaultInformer)
+}
+
+func (f *namespacedSeccompProfileInformer) Lister() v1beta1.NamespacedSeccompProfileLister {
+	return v1beta1.NewNamespacedSeccompProfileLister(f.Informer().GetIndexer())
+}
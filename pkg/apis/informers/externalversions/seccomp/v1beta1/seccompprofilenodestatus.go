@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	time "time"
+
+	versioned "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned"
+	internalinterfaces "github.com/imjasonh/seccomp-profile/pkg/apis/informers/externalversions/internalinterfaces"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	seccompv1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// SeccompProfileNodeStatusInformer provides access to a shared informer and lister for
+// SeccompProfileNodeStatuses.
+type SeccompProfileNodeStatusInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1beta1.SeccompProfileNodeStatusLister
+}
+
+type seccompProfileNodeStatusInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewSeccompProfileNodeStatusInformer constructs a new informer for SeccompProfileNodeStatus type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewSeccompProfileNodeStatusInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredSeccompProfileNodeStatusInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredSeccompProfileNodeStatusInformer constructs a new informer for SeccompProfileNodeStatus type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredSeccompProfileNodeStatusInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SeccompV1beta1().SeccompProfileNodeStatuses(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SeccompV1beta1().SeccompProfileNodeStatuses(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&seccompv1beta1.SeccompProfileNodeStatus{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *seccompProfileNodeStatusInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredSeccompProfileNodeStatusInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *seccompProfileNodeStatusInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&seccompv1beta1.SeccompProfileNodeStatus{}, f.defaultInformer)
+}
+
+func (f *seccompProfileNodeStatusInformer) Lister() v1beta1.SeccompProfileNodeStatusLister {
+	return v1beta1.NewSeccompProfileNodeStatusLister(f.Informer().GetIndexer())
+}
@@ -26,7 +26,9 @@ import (
 
 	versioned "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned"
 	typedseccompv1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1alpha1"
+	typedseccompv1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/typed/seccomp/v1beta1"
 	v1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1alpha1"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -237,3 +239,407 @@ func (w *wrapSeccompV1alpha1SeccompProfileImpl) UpdateStatus(ctx context.Context
 func (w *wrapSeccompV1alpha1SeccompProfileImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return nil, errors.New("NYI: Watch")
 }
+
+// SeccompV1beta1 retrieves the SeccompV1beta1Client
+func (w *wrapClient) SeccompV1beta1() typedseccompv1beta1.SeccompV1beta1Interface {
+	return &wrapSeccompV1beta1{
+		dyn: w.dyn,
+	}
+}
+
+type wrapSeccompV1beta1 struct {
+	dyn dynamic.Interface
+}
+
+func (w *wrapSeccompV1beta1) RESTClient() rest.Interface {
+	panic("RESTClient called on dynamic client!")
+}
+
+func (w *wrapSeccompV1beta1) NamespacedSeccompProfiles(namespace string) typedseccompv1beta1.NamespacedSeccompProfileInterface {
+	return &wrapSeccompV1beta1NamespacedSeccompProfileImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "seccomp.imjasonh.dev",
+			Version:  "v1beta1",
+			Resource: "namespacedseccompprofiles",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapSeccompV1beta1NamespacedSeccompProfileImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedseccompv1beta1.NamespacedSeccompProfileInterface = (*wrapSeccompV1beta1NamespacedSeccompProfileImpl)(nil)
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) Create(ctx context.Context, in *v1beta1.NamespacedSeccompProfile, opts v1.CreateOptions) (*v1beta1.NamespacedSeccompProfile, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "NamespacedSeccompProfile",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.NamespacedSeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.NamespacedSeccompProfile, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.NamespacedSeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) List(ctx context.Context, opts v1.ListOptions) (*v1beta1.NamespacedSeccompProfileList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.NamespacedSeccompProfileList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.NamespacedSeccompProfile, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.NamespacedSeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) Update(ctx context.Context, in *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (*v1beta1.NamespacedSeccompProfile, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "NamespacedSeccompProfile",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.NamespacedSeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) UpdateStatus(ctx context.Context, in *v1beta1.NamespacedSeccompProfile, opts v1.UpdateOptions) (*v1beta1.NamespacedSeccompProfile, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "NamespacedSeccompProfile",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.NamespacedSeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1NamespacedSeccompProfileImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapSeccompV1beta1) SeccompProfiles() typedseccompv1beta1.SeccompProfileInterface {
+	return &wrapSeccompV1beta1SeccompProfileImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "seccomp.imjasonh.dev",
+			Version:  "v1beta1",
+			Resource: "seccompprofiles",
+		}),
+	}
+}
+
+type wrapSeccompV1beta1SeccompProfileImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typedseccompv1beta1.SeccompProfileInterface = (*wrapSeccompV1beta1SeccompProfileImpl)(nil)
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) Create(ctx context.Context, in *v1beta1.SeccompProfile, opts v1.CreateOptions) (*v1beta1.SeccompProfile, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "SeccompProfile",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.SeccompProfile, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) List(ctx context.Context, opts v1.ListOptions) (*v1beta1.SeccompProfileList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfileList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.SeccompProfile, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) Update(ctx context.Context, in *v1beta1.SeccompProfile, opts v1.UpdateOptions) (*v1beta1.SeccompProfile, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "SeccompProfile",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) UpdateStatus(ctx context.Context, in *v1beta1.SeccompProfile, opts v1.UpdateOptions) (*v1beta1.SeccompProfile, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "SeccompProfile",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfile{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapSeccompV1beta1) SeccompProfileNodeStatuses(namespace string) typedseccompv1beta1.SeccompProfileNodeStatusInterface {
+	return &wrapSeccompV1beta1SeccompProfileNodeStatusImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "seccomp.imjasonh.dev",
+			Version:  "v1beta1",
+			Resource: "seccompprofilenodestatuses",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapSeccompV1beta1SeccompProfileNodeStatusImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedseccompv1beta1.SeccompProfileNodeStatusInterface = (*wrapSeccompV1beta1SeccompProfileNodeStatusImpl)(nil)
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) Create(ctx context.Context, in *v1beta1.SeccompProfileNodeStatus, opts v1.CreateOptions) (*v1beta1.SeccompProfileNodeStatus, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "SeccompProfileNodeStatus",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfileNodeStatus{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.SeccompProfileNodeStatus, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfileNodeStatus{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) List(ctx context.Context, opts v1.ListOptions) (*v1beta1.SeccompProfileNodeStatusList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfileNodeStatusList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.SeccompProfileNodeStatus, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfileNodeStatus{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) Update(ctx context.Context, in *v1beta1.SeccompProfileNodeStatus, opts v1.UpdateOptions) (*v1beta1.SeccompProfileNodeStatus, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "SeccompProfileNodeStatus",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfileNodeStatus{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) UpdateStatus(ctx context.Context, in *v1beta1.SeccompProfileNodeStatus, opts v1.UpdateOptions) (*v1beta1.SeccompProfileNodeStatus, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "seccomp.imjasonh.dev",
+		Version: "v1beta1",
+		Kind:    "SeccompProfileNodeStatus",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1beta1.SeccompProfileNodeStatus{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapSeccompV1beta1SeccompProfileNodeStatusImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
@@ -26,7 +26,7 @@ import (
 
 	versionedscheme "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned/scheme"
 	client "github.com/imjasonh/seccomp-profile/pkg/apis/injection/client"
-	seccompprofile "github.com/imjasonh/seccomp-profile/pkg/apis/injection/informers/seccomp/v1alpha1/seccompprofile"
+	seccompprofile "github.com/imjasonh/seccomp-profile/pkg/apis/injection/informers/seccomp/v1beta1/seccompprofile"
 	zap "go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
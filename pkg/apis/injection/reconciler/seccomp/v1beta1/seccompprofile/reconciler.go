@@ -24,8 +24,8 @@ import (
 	fmt "fmt"
 
 	versioned "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned"
-	seccompv1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1alpha1"
-	v1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1alpha1"
+	seccompv1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
 	zap "go.uber.org/zap"
 	v1 "k8s.io/api/core/v1"
 	equality "k8s.io/apimachinery/pkg/api/equality"
@@ -42,40 +42,40 @@ import (
 )
 
 // Interface defines the strongly typed interfaces to be implemented by a
-// controller reconciling v1alpha1.SeccompProfile.
+// controller reconciling v1beta1.SeccompProfile.
 type Interface interface {
-	// ReconcileKind implements custom logic to reconcile v1alpha1.SeccompProfile. Any changes
+	// ReconcileKind implements custom logic to reconcile v1beta1.SeccompProfile. Any changes
 	// to the objects .Status or .Finalizers will be propagated to the stored
 	// object. It is recommended that implementors do not call any update calls
 	// for the Kind inside of ReconcileKind, it is the responsibility of the calling
 	// controller to propagate those properties. The resource passed to ReconcileKind
 	// will always have an empty deletion timestamp.
-	ReconcileKind(ctx context.Context, o *v1alpha1.SeccompProfile) reconciler.Event
+	ReconcileKind(ctx context.Context, o *v1beta1.SeccompProfile) reconciler.Event
 }
 
 // Finalizer defines the strongly typed interfaces to be implemented by a
-// controller finalizing v1alpha1.SeccompProfile.
+// controller finalizing v1beta1.SeccompProfile.
 type Finalizer interface {
-	// FinalizeKind implements custom logic to finalize v1alpha1.SeccompProfile. Any changes
+	// FinalizeKind implements custom logic to finalize v1beta1.SeccompProfile. Any changes
 	// to the objects .Status or .Finalizers will be ignored. Returning a nil or
 	// Normal type reconciler.Event will allow the finalizer to be deleted on
 	// the resource. The resource passed to FinalizeKind will always have a set
 	// deletion timestamp.
-	FinalizeKind(ctx context.Context, o *v1alpha1.SeccompProfile) reconciler.Event
+	FinalizeKind(ctx context.Context, o *v1beta1.SeccompProfile) reconciler.Event
 }
 
 // ReadOnlyInterface defines the strongly typed interfaces to be implemented by a
-// controller reconciling v1alpha1.SeccompProfile if they want to process resources for which
+// controller reconciling v1beta1.SeccompProfile if they want to process resources for which
 // they are not the leader.
 type ReadOnlyInterface interface {
-	// ObserveKind implements logic to observe v1alpha1.SeccompProfile.
+	// ObserveKind implements logic to observe v1beta1.SeccompProfile.
 	// This method should not write to the API.
-	ObserveKind(ctx context.Context, o *v1alpha1.SeccompProfile) reconciler.Event
+	ObserveKind(ctx context.Context, o *v1beta1.SeccompProfile) reconciler.Event
 }
 
-type doReconcile func(ctx context.Context, o *v1alpha1.SeccompProfile) reconciler.Event
+type doReconcile func(ctx context.Context, o *v1beta1.SeccompProfile) reconciler.Event
 
-// reconcilerImpl implements controller.Reconciler for v1alpha1.SeccompProfile resources.
+// reconcilerImpl implements controller.Reconciler for v1beta1.SeccompProfile resources.
 type reconcilerImpl struct {
 	// LeaderAwareFuncs is inlined to help us implement reconciler.LeaderAware.
 	reconciler.LeaderAwareFuncs
@@ -84,7 +84,7 @@ type reconcilerImpl struct {
 	Client versioned.Interface
 
 	// Listers index properties about resources.
-	Lister seccompv1alpha1.SeccompProfileLister
+	Lister seccompv1beta1.SeccompProfileLister
 
 	// Recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
@@ -111,7 +111,7 @@ var _ controller.Reconciler = (*reconcilerImpl)(nil)
 // Check that our generated Reconciler is always LeaderAware.
 var _ reconciler.LeaderAware = (*reconcilerImpl)(nil)
 
-func NewReconciler(ctx context.Context, logger *zap.SugaredLogger, client versioned.Interface, lister seccompv1alpha1.SeccompProfileLister, recorder record.EventRecorder, r Interface, options ...controller.Options) controller.Reconciler {
+func NewReconciler(ctx context.Context, logger *zap.SugaredLogger, client versioned.Interface, lister seccompv1beta1.SeccompProfileLister, recorder record.EventRecorder, r Interface, options ...controller.Options) controller.Reconciler {
 	// Check the options function input. It should be 0 or 1.
 	if len(options) > 1 {
 		logger.Fatal("Up to one options struct is supported, found: ", len(options))
@@ -308,13 +308,13 @@ func (r *reconcilerImpl) Reconcile(ctx context.Context, key string) error {
 	return nil
 }
 
-func (r *reconcilerImpl) updateStatus(ctx context.Context, existing *v1alpha1.SeccompProfile, desired *v1alpha1.SeccompProfile) error {
+func (r *reconcilerImpl) updateStatus(ctx context.Context, existing *v1beta1.SeccompProfile, desired *v1beta1.SeccompProfile) error {
 	existing = existing.DeepCopy()
 	return reconciler.RetryUpdateConflicts(func(attempts int) (err error) {
 		// The first iteration tries to use the injectionInformer's state, subsequent attempts fetch the latest state via API.
 		if attempts > 0 {
 
-			getter := r.Client.SeccompV1alpha1().SeccompProfiles()
+			getter := r.Client.SeccompV1beta1().SeccompProfiles()
 
 			existing, err = getter.Get(ctx, desired.Name, metav1.GetOptions{})
 			if err != nil {
@@ -333,7 +333,7 @@ func (r *reconcilerImpl) updateStatus(ctx context.Context, existing *v1alpha1.Se
 
 		existing.Status = desired.Status
 
-		updater := r.Client.SeccompV1alpha1().SeccompProfiles()
+		updater := r.Client.SeccompV1beta1().SeccompProfiles()
 
 		_, err = updater.UpdateStatus(ctx, existing, metav1.UpdateOptions{})
 		return err
@@ -343,7 +343,7 @@ func (r *reconcilerImpl) updateStatus(ctx context.Context, existing *v1alpha1.Se
 // updateFinalizersFiltered will update the Finalizers of the resource.
 // TODO: this method could be generic and sync all finalizers. For now it only
 // updates defaultFinalizerName or its override.
-func (r *reconcilerImpl) updateFinalizersFiltered(ctx context.Context, resource *v1alpha1.SeccompProfile, desiredFinalizers sets.String) (*v1alpha1.SeccompProfile, error) {
+func (r *reconcilerImpl) updateFinalizersFiltered(ctx context.Context, resource *v1beta1.SeccompProfile, desiredFinalizers sets.String) (*v1beta1.SeccompProfile, error) {
 	// Don't modify the informers copy.
 	existing := resource.DeepCopy()
 
@@ -381,7 +381,7 @@ func (r *reconcilerImpl) updateFinalizersFiltered(ctx context.Context, resource
 		return resource, err
 	}
 
-	patcher := r.Client.SeccompV1alpha1().SeccompProfiles()
+	patcher := r.Client.SeccompV1beta1().SeccompProfiles()
 
 	resourceName := resource.Name
 	updated, err := patcher.Patch(ctx, resourceName, types.MergePatchType, patch, metav1.PatchOptions{})
@@ -395,7 +395,7 @@ func (r *reconcilerImpl) updateFinalizersFiltered(ctx context.Context, resource
 	return updated, err
 }
 
-func (r *reconcilerImpl) setFinalizerIfFinalizer(ctx context.Context, resource *v1alpha1.SeccompProfile) (*v1alpha1.SeccompProfile, error) {
+func (r *reconcilerImpl) setFinalizerIfFinalizer(ctx context.Context, resource *v1beta1.SeccompProfile) (*v1beta1.SeccompProfile, error) {
 	if _, ok := r.reconciler.(Finalizer); !ok {
 		return resource, nil
 	}
@@ -411,7 +411,7 @@ func (r *reconcilerImpl) setFinalizerIfFinalizer(ctx context.Context, resource *
 	return r.updateFinalizersFiltered(ctx, resource, finalizers)
 }
 
-func (r *reconcilerImpl) clearFinalizer(ctx context.Context, resource *v1alpha1.SeccompProfile, reconcileEvent reconciler.Event) (*v1alpha1.SeccompProfile, error) {
+func (r *reconcilerImpl) clearFinalizer(ctx context.Context, resource *v1beta1.SeccompProfile, reconcileEvent reconciler.Event) (*v1beta1.SeccompProfile, error) {
 	if _, ok := r.reconciler.(Finalizer); !ok {
 		return resource, nil
 	}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespacedSeccompProfileLister helps list NamespacedSeccompProfiles.
+// All objects returned here must be treated as read-only.
+type NamespacedSeccompProfileLister interface {
+	// List lists all NamespacedSeccompProfiles in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.NamespacedSeccompProfile, err error)
+	// NamespacedSeccompProfiles returns an object that can list and get NamespacedSeccompProfiles.
+	NamespacedSeccompProfiles(namespace string) NamespacedSeccompProfileNamespaceLister
+	NamespacedSeccompProfileListerExpansion
+}
+
+// namespacedSeccompProfileLister implements the NamespacedSeccompProfileLister interface.
+type namespacedSeccompProfileLister struct {
+	indexer cache.Indexer
+}
+
+// NewNamespacedSeccompProfileLister returns a new NamespacedSeccompProfileLister.
+func NewNamespacedSeccompProfileLister(indexer cache.Indexer) NamespacedSeccompProfileLister {
+	return &namespacedSeccompProfileLister{indexer: indexer}
+}
+
+// List lists all NamespacedSeccompProfiles in the indexer.
+func (s *namespacedSeccompProfileLister) List(selector labels.Selector) (ret []*v1beta1.NamespacedSeccompProfile, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.NamespacedSeccompProfile))
+	})
+	return ret, err
+}
+
+// NamespacedSeccompProfiles returns an object that can list and get NamespacedSeccompProfiles.
+func (s *namespacedSeccompProfileLister) NamespacedSeccompProfiles(namespace string) NamespacedSeccompProfileNamespaceLister {
+	return namespacedSeccompProfileNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// NamespacedSeccompProfileNamespaceLister helps list and get NamespacedSeccompProfiles.
+// All objects returned here must be treated as read-only.
+type NamespacedSeccompProfileNamespaceLister interface {
+	// List lists all NamespacedSeccompProfiles in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.NamespacedSeccompProfile, err error)
+	// Get retrieves the NamespacedSeccompProfile from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.NamespacedSeccompProfile, error)
+	NamespacedSeccompProfileNamespaceListerExpansion
+}
+
+// namespacedSeccompProfileNamespaceLister implements the NamespacedSeccompProfileNamespaceLister
+// interface.
+type namespacedSeccompProfileNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all NamespacedSeccompProfiles in the indexer for a given namespace.
+func (s namespacedSeccompProfileNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.NamespacedSeccompProfile, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.NamespacedSeccompProfile))
+	})
+	return ret, err
+}
+
+// Get retrieves the NamespacedSeccompProfile from the indexer for a given namespace and name.
+func (s namespacedSeccompProfileNamespaceLister) Get(name string) (*v1beta1.NamespacedSeccompProfile, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("namespacedseccompprofile"), name)
+	}
+	return obj.(*v1beta1.NamespacedSeccompProfile), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SeccompProfileNodeStatusLister helps list SeccompProfileNodeStatuses.
+// All objects returned here must be treated as read-only.
+type SeccompProfileNodeStatusLister interface {
+	// List lists all SeccompProfileNodeStatuses in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.SeccompProfileNodeStatus, err error)
+	// SeccompProfileNodeStatuses returns an object that can list and get SeccompProfileNodeStatuses.
+	SeccompProfileNodeStatuses(namespace string) SeccompProfileNodeStatusNamespaceLister
+	SeccompProfileNodeStatusListerExpansion
+}
+
+// seccompProfileNodeStatusLister implements the SeccompProfileNodeStatusLister interface.
+type seccompProfileNodeStatusLister struct {
+	indexer cache.Indexer
+}
+
+// NewSeccompProfileNodeStatusLister returns a new SeccompProfileNodeStatusLister.
+func NewSeccompProfileNodeStatusLister(indexer cache.Indexer) SeccompProfileNodeStatusLister {
+	return &seccompProfileNodeStatusLister{indexer: indexer}
+}
+
+// List lists all SeccompProfileNodeStatuses in the indexer.
+func (s *seccompProfileNodeStatusLister) List(selector labels.Selector) (ret []*v1beta1.SeccompProfileNodeStatus, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.SeccompProfileNodeStatus))
+	})
+	return ret, err
+}
+
+// SeccompProfileNodeStatuses returns an object that can list and get SeccompProfileNodeStatuses.
+func (s *seccompProfileNodeStatusLister) SeccompProfileNodeStatuses(namespace string) SeccompProfileNodeStatusNamespaceLister {
+	return seccompProfileNodeStatusNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// SeccompProfileNodeStatusNamespaceLister helps list and get SeccompProfileNodeStatuses.
+// All objects returned here must be treated as read-only.
+type SeccompProfileNodeStatusNamespaceLister interface {
+	// List lists all SeccompProfileNodeStatuses in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.SeccompProfileNodeStatus, err error)
+	// Get retrieves the SeccompProfileNodeStatus from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.SeccompProfileNodeStatus, error)
+	SeccompProfileNodeStatusNamespaceListerExpansion
+}
+
+// seccompProfileNodeStatusNamespaceLister implements the SeccompProfileNodeStatusNamespaceLister
+// interface.
+type seccompProfileNodeStatusNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all SeccompProfileNodeStatuses in the indexer for a given namespace.
+func (s seccompProfileNodeStatusNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.SeccompProfileNodeStatus, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.SeccompProfileNodeStatus))
+	})
+	return ret, err
+}
+
+// Get retrieves the SeccompProfileNodeStatus from the indexer for a given namespace and name.
+func (s seccompProfileNodeStatusNamespaceLister) Get(name string) (*v1beta1.SeccompProfileNodeStatus, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("seccompprofilenodestatus"), name)
+	}
+	return obj.(*v1beta1.SeccompProfileNodeStatus), nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+// V1beta1FieldsAnnotation holds, as JSON, the fields of a v1beta1
+// SeccompProfile that v1alpha1 can't represent, so that converting to
+// v1alpha1 and back doesn't lose them.
+const V1beta1FieldsAnnotation = "seccomp.imjasonh.dev/v1beta1-fields"
+
+// v1beta1Fields are the fields of a v1beta1 SeccompProfile that v1alpha1
+// can't represent.
+type v1beta1Fields struct {
+	Spec   *v1beta1.SeccompProfileSpec   `json:"spec,omitempty"`
+	Status *v1beta1.SeccompProfileStatus `json:"status,omitempty"`
+}
+
+// ConvertTo implements apis.Convertible
+func (sp *SeccompProfile) ConvertTo(ctx context.Context, to apis.Convertible) error {
+	switch sink := to.(type) {
+	case *v1beta1.SeccompProfile:
+		sink.ObjectMeta = sp.ObjectMeta
+		sp.Spec.ConvertTo(ctx, &sink.Spec)
+		sink.Status = v1beta1.SeccompProfileStatus{Status: sp.Status.Status}
+
+		v, ok := sp.Annotations[V1beta1FieldsAnnotation]
+		if !ok {
+			return nil
+		}
+		sink.Annotations = withoutFields(sp.Annotations)
+		var fields v1beta1Fields
+		if err := json.Unmarshal([]byte(v), &fields); err != nil {
+			return fmt.Errorf("unable to parse %s annotation: %w", V1beta1FieldsAnnotation, err)
+		}
+		if f := fields.Spec; f != nil {
+			sink.Spec.BaseProfileRef = f.BaseProfileRef
+			sink.Spec.RemoveSyscalls = f.RemoveSyscalls
+			sink.Spec.TranslateSyscalls = f.TranslateSyscalls
+		}
+		if f := fields.Status; f != nil {
+			sink.Status.ContentHash = f.ContentHash
+			sink.Status.LocalhostProfile = f.LocalhostProfile
+			sink.Status.Distribution = f.Distribution
+			sink.Status.TranslatedSyscalls = f.TranslatedSyscalls
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// ConvertFrom implements apis.Convertible
+func (sp *SeccompProfile) ConvertFrom(ctx context.Context, from apis.Convertible) error {
+	switch source := from.(type) {
+	case *v1beta1.SeccompProfile:
+		sp.ObjectMeta = source.ObjectMeta
+		sp.Spec.ConvertFrom(ctx, &source.Spec)
+		sp.Status.Status = source.Status.Status
+
+		sp.Annotations = withoutFields(source.Annotations)
+		var fields v1beta1Fields
+		if s := source.Spec; s.BaseProfileRef != nil || len(s.RemoveSyscalls) != 0 || s.TranslateSyscalls {
+			fields.Spec = &v1beta1.SeccompProfileSpec{
+				BaseProfileRef:    s.BaseProfileRef,
+				RemoveSyscalls:    s.RemoveSyscalls,
+				TranslateSyscalls: s.TranslateSyscalls,
+			}
+		}
+		if s := source.Status; s.ContentHash != "" || s.LocalhostProfile != "" || s.Distribution != nil || len(s.TranslatedSyscalls) != 0 {
+			fields.Status = &v1beta1.SeccompProfileStatus{
+				ContentHash:        s.ContentHash,
+				LocalhostProfile:   s.LocalhostProfile,
+				Distribution:       s.Distribution,
+				TranslatedSyscalls: s.TranslatedSyscalls,
+			}
+		}
+		if fields.Spec == nil && fields.Status == nil {
+			return nil
+		}
+		b, err := json.Marshal(fields)
+		if err != nil {
+			return err
+		}
+		sp.Annotations = kmeta.UnionMaps(sp.Annotations, map[string]string{V1beta1FieldsAnnotation: string(b)})
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// withoutFields returns the annotations without V1beta1FieldsAnnotation,
+// copying them rather than modifying the original object's if it's there.
+func withoutFields(annotations map[string]string) map[string]string {
+	if _, ok := annotations[V1beta1FieldsAnnotation]; !ok {
+		return annotations
+	}
+	out := kmeta.FilterMap(annotations, func(k string) bool { return k == V1beta1FieldsAnnotation })
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// ConvertTo converts the receiver into a v1beta1 SeccompProfileSpec.
+func (spec *SeccompProfileSpec) ConvertTo(ctx context.Context, sink *v1beta1.SeccompProfileSpec) {
+	if spec.Contents == nil {
+		sink.Contents = nil
+		return
+	}
+	sink.Contents = &v1beta1.SeccompProfileJSON{}
+	spec.Contents.ConvertTo(ctx, sink.Contents)
+}
+
+// ConvertFrom converts a v1beta1 SeccompProfileSpec into the receiver.
+func (spec *SeccompProfileSpec) ConvertFrom(ctx context.Context, source *v1beta1.SeccompProfileSpec) {
+	if source.Contents == nil {
+		spec.Contents = nil
+		return
+	}
+	spec.Contents = &SeccompProfileJSON{}
+	spec.Contents.ConvertFrom(ctx, source.Contents)
+}
+
+// ConvertTo converts the receiver into a v1beta1 SeccompProfileJSON. A
+// syscall rule's .name is converted into .names.
+func (c *SeccompProfileJSON) ConvertTo(ctx context.Context, sink *v1beta1.SeccompProfileJSON) {
+	in := c.DeepCopy()
+	*sink = v1beta1.SeccompProfileJSON{
+		DefaultAction:    v1beta1.Action(in.DefaultAction),
+		DefaultErrnoRet:  in.DefaultErrnoRet,
+		Architectures:    in.Architectures,
+		ListenerPath:     in.ListenerPath,
+		ListenerMetadata: in.ListenerMetadata,
+	}
+	for _, am := range in.ArchMap {
+		sink.ArchMap = append(sink.ArchMap, v1beta1.SeccompProfileArchMap(am))
+	}
+	for _, f := range in.Flags {
+		sink.Flags = append(sink.Flags, v1beta1.Flag(f))
+	}
+	for _, s := range in.Syscalls {
+		names := s.Names
+		if s.Name != "" {
+			names = append([]string{s.Name}, names...)
+		}
+		out := v1beta1.SeccompProfileSyscall{
+			Names:    names,
+			Action:   v1beta1.Action(s.Action),
+			ErrnoRet: s.ErrnoRet,
+			Includes: (*v1beta1.SeccompProfileFilter)(s.Includes),
+			Excludes: (*v1beta1.SeccompProfileFilter)(s.Excludes),
+		}
+		for _, a := range s.Args {
+			out.Args = append(out.Args, v1beta1.SeccompProfileArg{
+				Index:    a.Index,
+				Value:    a.Value,
+				ValueTwo: a.ValueTwo,
+				Op:       v1beta1.Operator(a.Op),
+			})
+		}
+		sink.Syscalls = append(sink.Syscalls, out)
+	}
+}
+
+// ConvertFrom converts a v1beta1 SeccompProfileJSON into the receiver.
+func (c *SeccompProfileJSON) ConvertFrom(ctx context.Context, source *v1beta1.SeccompProfileJSON) {
+	in := source.DeepCopy()
+	*c = SeccompProfileJSON{
+		DefaultAction:    Action(in.DefaultAction),
+		DefaultErrnoRet:  in.DefaultErrnoRet,
+		Architectures:    in.Architectures,
+		ListenerPath:     in.ListenerPath,
+		ListenerMetadata: in.ListenerMetadata,
+	}
+	for _, am := range in.ArchMap {
+		c.ArchMap = append(c.ArchMap, SeccompProfileArchMap(am))
+	}
+	for _, f := range in.Flags {
+		c.Flags = append(c.Flags, Flag(f))
+	}
+	for _, s := range in.Syscalls {
+		out := SeccompProfileSyscall{
+			Names:    s.Names,
+			Action:   Action(s.Action),
+			ErrnoRet: s.ErrnoRet,
+			Includes: (*SeccompProfileFilter)(s.Includes),
+			Excludes: (*SeccompProfileFilter)(s.Excludes),
+		}
+		for _, a := range s.Args {
+			out.Args = append(out.Args, SeccompProfileArg{
+				Index:    a.Index,
+				Value:    a.Value,
+				ValueTwo: a.ValueTwo,
+				Op:       Operator(a.Op),
+			})
+		}
+		c.Syscalls = append(c.Syscalls, out)
+	}
+}
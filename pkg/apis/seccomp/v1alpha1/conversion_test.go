@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+func TestConversionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	in := &SeccompProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "hardened"},
+		Spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction:   ActionErr,
+			DefaultErrnoRet: pointer.Uint(1),
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture:     "SCMP_ARCH_X86_64",
+				SubArchitectures: []string{"SCMP_ARCH_X86"},
+			}},
+			Flags:        []Flag{FlagLog},
+			ListenerPath: "/run/agent.sock",
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"personality"},
+				Action: ActionAllow,
+				Args:   []SeccompProfileArg{{Index: 0, Value: 8, Op: OpEqualTo}},
+			}, {
+				Names:    []string{"clone3"},
+				Action:   ActionErr,
+				ErrnoRet: pointer.Uint(38),
+				Includes: &SeccompProfileFilter{MinKernel: "5.3"},
+			}},
+		}},
+	}
+
+	var mid v1beta1.SeccompProfile
+	if err := in.ConvertTo(ctx, &mid); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	got := &SeccompProfile{}
+	if err := got.ConvertFrom(ctx, &mid); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	if diff := cmp.Diff(in, got); diff != "" {
+		t.Errorf("round trip (-want +got): %s", diff)
+	}
+}
+
+func TestConversionRoundTripV1beta1(t *testing.T) {
+	ctx := context.Background()
+	in := &v1beta1.SeccompProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "hardened",
+			Annotations: map[string]string{"team": "platform"},
+		},
+		Spec: v1beta1.SeccompProfileSpec{
+			BaseProfileRef:    &v1beta1.BaseProfileReference{Name: "baseline"},
+			RemoveSyscalls:    []string{"mount", "ptrace"},
+			TranslateSyscalls: true,
+			Contents: &v1beta1.SeccompProfileJSON{
+				DefaultAction: v1beta1.ActionErr,
+				Syscalls: []v1beta1.SeccompProfileSyscall{{
+					Names:  []string{"read", "write"},
+					Action: v1beta1.ActionAllow,
+				}},
+			},
+		},
+		Status: v1beta1.SeccompProfileStatus{
+			Status: duckv1.Status{
+				ObservedGeneration: 2,
+				Conditions:         duckv1.Conditions{{Type: apis.ConditionReady, Status: corev1.ConditionTrue}},
+			},
+			ContentHash:        "sha256:abc",
+			LocalhostProfile:   "profiles/hardened.json",
+			Distribution:       &v1beta1.SeccompProfileDistribution{Nodes: 2, Written: 2, Summary: "2/2"},
+			TranslatedSyscalls: []string{"openat (for open)"},
+		},
+	}
+	want := in.DeepCopy()
+
+	var mid SeccompProfile
+	if err := mid.ConvertFrom(ctx, in); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	if _, ok := mid.Annotations[V1beta1FieldsAnnotation]; !ok {
+		t.Errorf("ConvertFrom() annotations = %v, want %s", mid.Annotations, V1beta1FieldsAnnotation)
+	}
+	got := &v1beta1.SeccompProfile{}
+	if err := mid.ConvertTo(ctx, got); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("round trip (-want +got): %s", diff)
+	}
+	// Converting doesn't modify the original.
+	if diff := cmp.Diff(want, in); diff != "" {
+		t.Errorf("ConvertFrom() modified its source (-want +got): %s", diff)
+	}
+}
+
+func TestConvertName(t *testing.T) {
+	in := &SeccompProfileJSON{
+		DefaultAction: ActionErr,
+		Syscalls: []SeccompProfileSyscall{{
+			Name:   "read",
+			Action: ActionAllow,
+		}},
+	}
+	var got v1beta1.SeccompProfileJSON
+	in.ConvertTo(context.Background(), &got)
+	want := v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionErr,
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:  []string{"read"},
+			Action: v1beta1.ActionAllow,
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ConvertTo() (-want +got): %s", diff)
+	}
+}
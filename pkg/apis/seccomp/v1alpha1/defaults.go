@@ -18,9 +18,37 @@ package v1alpha1
 
 import (
 	"context"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
 )
 
 // SetDefaults implements apis.Defaultable
+//
+// The profile is defaulted the same way as v1beta1, by converting it along
+// with the fields kept in V1beta1FieldsAnnotation, which also converts each
+// rule's .name into .names.
 func (sp *SeccompProfile) SetDefaults(ctx context.Context) {
-	// TODO: uniformly pretty-print or un-pretty-print to avoid semantic duplicates that only differ by whitespace.
+	if sp.Spec.nameConflict() >= 0 {
+		// Leave this for validation to reject.
+		return
+	}
+	sink := &v1beta1.SeccompProfile{}
+	if err := sp.ConvertTo(ctx, sink); err != nil {
+		// Leave an unparseable annotation for validation to reject.
+		return
+	}
+	sink.SetDefaults(ctx)
+	sp.ConvertFrom(ctx, sink)
+}
+
+// SetDefaults canonicalizes the spec the same way as v1beta1, which also
+// converts each rule's .name into .names.
+func (spec *SeccompProfileSpec) SetDefaults(ctx context.Context) {
+	if spec.Contents == nil || spec.nameConflict() >= 0 {
+		return
+	}
+	sink := &v1beta1.SeccompProfileSpec{}
+	spec.ConvertTo(ctx, sink)
+	sink.SetDefaults(ctx)
+	spec.ConvertFrom(ctx, sink)
 }
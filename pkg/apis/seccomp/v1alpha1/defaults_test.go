@@ -0,0 +1,75 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+func TestSetDefaults(t *testing.T) {
+	spec := &SeccompProfileSpec{Contents: &SeccompProfileJSON{
+		DefaultAction: ActionErr,
+		Syscalls: []SeccompProfileSyscall{{
+			Name:   "write",
+			Action: ActionAllow,
+		}, {
+			Names:  []string{"read", "write"},
+			Action: ActionAllow,
+		}},
+	}}
+	spec.SetDefaults(context.Background())
+
+	want := &SeccompProfileSpec{Contents: &SeccompProfileJSON{
+		DefaultAction: ActionErr,
+		Syscalls: []SeccompProfileSyscall{{
+			Names:  []string{"read", "write"},
+			Action: ActionAllow,
+		}},
+	}}
+	if diff := cmp.Diff(want, spec); diff != "" {
+		t.Errorf("SetDefaults() (-want +got): %s", diff)
+	}
+}
+
+func TestSetDefaultsWithV1beta1Fields(t *testing.T) {
+	// Defaulting applies to the fields kept in the annotation, and doesn't
+	// require contents.
+	sp := &SeccompProfile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "profile",
+			Annotations: map[string]string{V1beta1FieldsAnnotation: `{"spec":{"baseProfileRef":{"name":"baseline"},"removeSyscalls":["ptrace","mount","ptrace"]}}`},
+		},
+	}
+	sp.SetDefaults(context.Background())
+
+	var got v1beta1.SeccompProfile
+	if err := sp.ConvertTo(context.Background(), &got); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if diff := cmp.Diff([]string{"mount", "ptrace"}, got.Spec.RemoveSyscalls); diff != "" {
+		t.Errorf("removeSyscalls (-want +got): %s", diff)
+	}
+	if got.Spec.BaseProfileRef == nil || got.Spec.BaseProfileRef.Name != "baseline" {
+		t.Errorf("baseProfileRef = %+v, want baseline", got.Spec.BaseProfileRef)
+	}
+}
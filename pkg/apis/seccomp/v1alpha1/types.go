@@ -25,9 +25,10 @@ import (
 
 // SeccompProfile represents a seccomp profile to distribute to nodes.
 //
+// Deprecated: use v1beta1.SeccompProfile.
+//
 // +genclient
 // +genclient:nonNamespaced
-// +genreconciler
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type SeccompProfile struct {
 	metav1.TypeMeta `json:",inline"`
@@ -44,9 +45,10 @@ type SeccompProfile struct {
 }
 
 var (
-	// Check that SeccompProfile can be validated and defaulted.
+	// Check that SeccompProfile can be validated, defaulted and converted.
 	_ apis.Validatable   = (*SeccompProfile)(nil)
 	_ apis.Defaultable   = (*SeccompProfile)(nil)
+	_ apis.Convertible   = (*SeccompProfile)(nil)
 	_ kmeta.OwnerRefable = (*SeccompProfile)(nil)
 	// Check that the type conforms to the duck Knative Resource shape.
 	_ duckv1.KRShaped = (*SeccompProfile)(nil)
@@ -59,16 +61,102 @@ type SeccompProfileSpec struct {
 }
 
 type SeccompProfileJSON struct {
-	DefaultAction Action                  `json:"defaultAction"`
-	Architectures []string                `json:"architectures,omitempty"`
-	Syscalls      []SeccompProfileSyscall `json:"syscalls,omitempty"`
+	DefaultAction Action `json:"defaultAction"`
+	// DefaultErrnoRet is the errno returned by the default action, if it is
+	// SCMP_ACT_ERRNO or SCMP_ACT_TRACE.
+	// +optional
+	DefaultErrnoRet *uint    `json:"defaultErrnoRet,omitempty"`
+	Architectures   []string `json:"architectures,omitempty"`
+	// ArchMap is the Docker-format alternative to Architectures, listing
+	// each main architecture along with its sub-architectures.
+	// +optional
+	ArchMap  []SeccompProfileArchMap `json:"archMap,omitempty"`
+	Syscalls []SeccompProfileSyscall `json:"syscalls,omitempty"`
+	// Flags are passed to the seccomp(2) syscall when the filter is loaded.
+	// +optional
+	Flags []Flag `json:"flags,omitempty"`
+	// ListenerPath is the path of a UNIX socket the runtime will pass the
+	// seccomp notify file descriptor to. It is required to use
+	// SCMP_ACT_NOTIFY.
+	// +optional
+	ListenerPath string `json:"listenerPath,omitempty"`
+	// ListenerMetadata is opaque data passed to the seccomp agent listening
+	// on ListenerPath.
+	// +optional
+	ListenerMetadata string `json:"listenerMetadata,omitempty"`
+}
+
+// SeccompProfileArchMap maps a main architecture to the sub-architectures
+// that should be allowed alongside it.
+type SeccompProfileArchMap struct {
+	Architecture     string   `json:"architecture"`
+	SubArchitectures []string `json:"subArchitectures,omitempty"`
+}
+
+// AllArchitectures returns the architectures the profile applies to, from
+// either Architectures or the flattened ArchMap.
+func (c *SeccompProfileJSON) AllArchitectures() []string {
+	if len(c.ArchMap) == 0 {
+		return c.Architectures
+	}
+	var out []string
+	for _, am := range c.ArchMap {
+		out = append(out, am.Architecture)
+		out = append(out, am.SubArchitectures...)
+	}
+	return out
 }
 
 type SeccompProfileSyscall struct {
-	Name   string   `json:"name"`
-	Names  []string `json:"names,omitempty"`
-	Action Action   `json:"action"`
-	Args   []string `json:"args,omitempty"`
+	Name   string              `json:"name"`
+	Names  []string            `json:"names,omitempty"`
+	Action Action              `json:"action"`
+	Args   []SeccompProfileArg `json:"args,omitempty"`
+	// ErrnoRet is the errno returned by the action, if it is SCMP_ACT_ERRNO
+	// or SCMP_ACT_TRACE.
+	// +optional
+	ErrnoRet *uint `json:"errnoRet,omitempty"`
+	// Includes limits the rule to nodes and containers matching the filter.
+	// +optional
+	Includes *SeccompProfileFilter `json:"includes,omitempty"`
+	// Excludes skips the rule on nodes and containers matching the filter.
+	// +optional
+	Excludes *SeccompProfileFilter `json:"excludes,omitempty"`
+}
+
+// SeccompProfileFilter conditionally applies a syscall rule, in the same
+// shape as Docker's default profile.
+//
+// Filters are resolved by the controller on each node before the profile
+// is written, since container runtimes don't all support them. Caps are
+// matched against the capabilities containers get by default.
+type SeccompProfileFilter struct {
+	// Arches are architectures, using Go's GOARCH names (plus "x86" and
+	// "x32"), that the filter matches.
+	// +optional
+	Arches []string `json:"arches,omitempty"`
+	// Caps are capabilities, such as CAP_SYS_ADMIN, that the filter matches.
+	// +optional
+	Caps []string `json:"caps,omitempty"`
+	// MinKernel is the minimum kernel version, as "<major>.<minor>", that
+	// the filter matches.
+	// +optional
+	MinKernel string `json:"minKernel,omitempty"`
+}
+
+// SeccompProfileArg filters a syscall based on the value of one of its
+// arguments, in the same shape used by OCI runtimes and Docker.
+type SeccompProfileArg struct {
+	// Index is the zero-based index of the syscall argument to compare.
+	Index uint `json:"index"`
+	// Value is the value to compare the argument against.
+	Value uint64 `json:"value"`
+	// ValueTwo is the second value used by SCMP_CMP_MASKED_EQ, where Value
+	// is the mask.
+	// +optional
+	ValueTwo uint64 `json:"valueTwo,omitempty"`
+	// Op is the comparison operator.
+	Op Operator `json:"op"`
 }
 
 // SeccompProfileStatus communicates the observed state of the SeccompProfile (from the controller).
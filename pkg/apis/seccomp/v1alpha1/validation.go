@@ -22,6 +22,8 @@ import (
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"knative.dev/pkg/apis"
+
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
 )
 
 // SupportedVerbs returns the operations that validation should be called for.
@@ -34,44 +36,77 @@ func (sp *SeccompProfile) SupportedVerbs() []admissionregistrationv1.OperationTy
 }
 
 // Validate implements apis.Validatable
+//
+// Apart from .name, which was removed in v1beta1, the profile is validated
+// by converting it to v1beta1, along with the fields kept in
+// V1beta1FieldsAnnotation, so that profiles extending others are validated
+// as a whole.
 func (sp *SeccompProfile) Validate(ctx context.Context) *apis.FieldError {
-	return sp.Spec.Validate(ctx).ViaField("spec")
+	if err := sp.Spec.Validate(ctx); err != nil {
+		return err.ViaField("spec")
+	}
+	var sink v1beta1.SeccompProfile
+	if err := sp.ConvertTo(ctx, &sink); err != nil {
+		return apis.ErrInvalidValue(sp.Annotations[V1beta1FieldsAnnotation], "metadata.annotations", err.Error())
+	}
+	return sink.Validate(ctx)
 }
 
 type Action string
 
 const (
-	ActionLog   Action = "SCMP_ACT_LOG"
-	ActionErr   Action = "SCMP_ACT_ERRNO"
-	ActionAllow Action = "SCMP_ACT_ALLOW"
+	ActionLog         Action = "SCMP_ACT_LOG"
+	ActionErr         Action = "SCMP_ACT_ERRNO"
+	ActionAllow       Action = "SCMP_ACT_ALLOW"
+	ActionKill        Action = "SCMP_ACT_KILL"
+	ActionKillProcess Action = "SCMP_ACT_KILL_PROCESS"
+	ActionKillThread  Action = "SCMP_ACT_KILL_THREAD"
+	ActionTrap        Action = "SCMP_ACT_TRAP"
+	ActionTrace       Action = "SCMP_ACT_TRACE"
+	ActionNotify      Action = "SCMP_ACT_NOTIFY"
 )
 
-func (a Action) Valid() error {
-	switch a {
-	case ActionLog, ActionErr, ActionAllow:
-		return nil
-	default:
-		return fmt.Errorf("unknown action: %s", a)
-	}
-}
+type Operator string
 
-// Validate implements apis.Validatable
+const (
+	OpNotEqual     Operator = "SCMP_CMP_NE"
+	OpLessThan     Operator = "SCMP_CMP_LT"
+	OpLessEqual    Operator = "SCMP_CMP_LE"
+	OpEqualTo      Operator = "SCMP_CMP_EQ"
+	OpGreaterEqual Operator = "SCMP_CMP_GE"
+	OpGreaterThan  Operator = "SCMP_CMP_GT"
+	OpMaskedEqual  Operator = "SCMP_CMP_MASKED_EQ"
+)
+
+type Flag string
+
+const (
+	FlagLog              Flag = "SECCOMP_FILTER_FLAG_LOG"
+	FlagSpecAllow        Flag = "SECCOMP_FILTER_FLAG_SPEC_ALLOW"
+	FlagTSync            Flag = "SECCOMP_FILTER_FLAG_TSYNC"
+	FlagWaitKillableRecv Flag = "SECCOMP_FILTER_FLAG_WAIT_KILLABLE_RECV"
+)
+
+// Validate checks each rule's .name, which v1beta1 doesn't have and which
+// can't be combined with .names. The rest of the spec is validated with the
+// whole profile, by SeccompProfile.Validate.
 func (spec *SeccompProfileSpec) Validate(ctx context.Context) *apis.FieldError {
-	if spec.Contents == nil {
-		return apis.ErrMissingField("contents")
+	if i := spec.nameConflict(); i >= 0 {
+		return apis.ErrInvalidValue(spec.Contents, "contents.syscalls", fmt.Sprintf("item %d: cannot specify both .name and .names", i))
 	}
+	return nil
+}
 
-	if err := spec.Contents.DefaultAction.Valid(); err != nil {
-		return apis.ErrInvalidValue(spec.Contents, "contents.defaultAction", fmt.Sprintf("invalid default action: %v", err))
+// nameConflict returns the index of the first rule with both .name and
+// .names, or -1 if there's none.
+func (spec *SeccompProfileSpec) nameConflict() int {
+	if spec.Contents == nil {
+		return -1
 	}
 	for i, s := range spec.Contents.Syscalls {
-		if err := s.Action.Valid(); err != nil {
-			return apis.ErrInvalidValue(spec.Contents, "contents.syscalls.action", fmt.Sprintf("item %d: invalid action: %v", i, err))
-		}
 		if s.Name != "" && len(s.Names) != 0 {
-			return apis.ErrInvalidValue(spec.Contents, "contents.syscalls", fmt.Sprintf("item %d: cannot specify both .name and .names", i))
+			return i
 		}
 	}
-
-	return nil
+	return -1
 }
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestValidation(t *testing.T) {
+	for _, c := range []struct {
+		desc        string
+		annotations map[string]string
+		spec        SeccompProfileSpec
+		wantErr     bool
+	}{{
+		desc:    "missing contents",
+		spec:    SeccompProfileSpec{},
+		wantErr: true,
+	}, {
+		desc: "name",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Name:   "read",
+				Action: ActionAllow,
+			}},
+		}},
+	}, {
+		desc: "both name and names",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Name:   "read",
+				Names:  []string{"write"},
+				Action: ActionAllow,
+			}},
+		}},
+		wantErr: true,
+	}, {
+		// Everything else is validated by v1beta1.
+		desc: "invalid after conversion",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Name:   "clone",
+				Action: ActionAllow,
+				Args:   []SeccompProfileArg{{Index: 6, Op: OpEqualTo}},
+			}},
+		}},
+		wantErr: true,
+	}, {
+		// A v1beta1 profile that extends another needs no contents.
+		desc:        "base profile in annotation",
+		annotations: map[string]string{V1beta1FieldsAnnotation: `{"spec":{"baseProfileRef":{"name":"baseline"}}}`},
+	}, {
+		desc:        "inherited defaultAction",
+		annotations: map[string]string{V1beta1FieldsAnnotation: `{"spec":{"baseProfileRef":{"builtin":"RuntimeDefault"}}}`},
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			Syscalls: []SeccompProfileSyscall{{
+				Name:   "ptrace",
+				Action: ActionErr,
+			}},
+		}},
+	}, {
+		desc:        "self-reference in annotation",
+		annotations: map[string]string{V1beta1FieldsAnnotation: `{"spec":{"baseProfileRef":{"name":"profile"}}}`},
+		wantErr:     true,
+	}, {
+		desc:        "name and builtin in annotation",
+		annotations: map[string]string{V1beta1FieldsAnnotation: `{"spec":{"baseProfileRef":{"name":"baseline","builtin":"RuntimeDefault"}}}`},
+		wantErr:     true,
+	}, {
+		desc:        "unparseable annotation",
+		annotations: map[string]string{V1beta1FieldsAnnotation: `{`},
+		wantErr:     true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			sp := &SeccompProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "profile", Annotations: c.annotations},
+				Spec:       c.spec,
+			}
+			err := sp.Validate(context.Background()).Filter(apis.ErrorLevel)
+			if gotErr := err != nil; gotErr != c.wantErr {
+				t.Errorf("Validate() = %v, wantErr %t", err, c.wantErr)
+			}
+		})
+	}
+}
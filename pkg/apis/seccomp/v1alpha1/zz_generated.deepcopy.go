@@ -53,14 +53,89 @@ func (in *SeccompProfile) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileArchMap) DeepCopyInto(out *SeccompProfileArchMap) {
+	*out = *in
+	if in.SubArchitectures != nil {
+		in, out := &in.SubArchitectures, &out.SubArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileArchMap.
+func (in *SeccompProfileArchMap) DeepCopy() *SeccompProfileArchMap {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileArchMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileArg) DeepCopyInto(out *SeccompProfileArg) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileArg.
+func (in *SeccompProfileArg) DeepCopy() *SeccompProfileArg {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileArg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileFilter) DeepCopyInto(out *SeccompProfileFilter) {
+	*out = *in
+	if in.Arches != nil {
+		in, out := &in.Arches, &out.Arches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Caps != nil {
+		in, out := &in.Caps, &out.Caps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileFilter.
+func (in *SeccompProfileFilter) DeepCopy() *SeccompProfileFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeccompProfileJSON) DeepCopyInto(out *SeccompProfileJSON) {
 	*out = *in
+	if in.DefaultErrnoRet != nil {
+		in, out := &in.DefaultErrnoRet, &out.DefaultErrnoRet
+		*out = new(uint)
+		**out = **in
+	}
 	if in.Architectures != nil {
 		in, out := &in.Architectures, &out.Architectures
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ArchMap != nil {
+		in, out := &in.ArchMap, &out.ArchMap
+		*out = make([]SeccompProfileArchMap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Syscalls != nil {
 		in, out := &in.Syscalls, &out.Syscalls
 		*out = make([]SeccompProfileSyscall, len(*in))
@@ -68,6 +143,11 @@ func (in *SeccompProfileJSON) DeepCopyInto(out *SeccompProfileJSON) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]Flag, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -162,9 +242,24 @@ func (in *SeccompProfileSyscall) DeepCopyInto(out *SeccompProfileSyscall) {
 	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
+		*out = make([]SeccompProfileArg, len(*in))
 		copy(*out, *in)
 	}
+	if in.ErrnoRet != nil {
+		in, out := &in.ErrnoRet, &out.ErrnoRet
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = new(SeccompProfileFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Excludes != nil {
+		in, out := &in.Excludes, &out.Excludes
+		*out = new(SeccompProfileFilter)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// SetDefaults implements apis.Defaultable
+func (sp *SeccompProfile) SetDefaults(ctx context.Context) {
+	sp.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable
+func (sp *NamespacedSeccompProfile) SetDefaults(ctx context.Context) {
+	sp.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults canonicalizes the profile, so that profiles which only differ
+// in how their rules are written are stored, and written to disk, the same.
+func (spec *SeccompProfileSpec) SetDefaults(ctx context.Context) {
+	if len(spec.RemoveSyscalls) != 0 {
+		spec.RemoveSyscalls = sets.NewString(spec.RemoveSyscalls...).List()
+	}
+	if spec.Contents == nil {
+		return
+	}
+
+	// Rules repeating the default action are kept, so that validation can
+	// warn about them, and since in a profile extending another they
+	// override the base profile's rules.
+	spec.Contents.canonicalize(false)
+}
+
+// Canonicalize is like SetDefaults, but also removes rules that take the
+// default action. It's for complete profiles, such as one merged with its
+// base profiles, where such rules have no effect.
+func (c *SeccompProfileJSON) Canonicalize() {
+	c.canonicalize(true)
+}
+
+// canonicalize sorts and dedupes each rule's syscall names and merges rules
+// that differ only in their names. If dropDefault is true, rules that take
+// the default action are removed.
+func (c *SeccompProfileJSON) canonicalize(dropDefault bool) {
+	var out []SeccompProfileSyscall
+	for _, s := range c.Syscalls {
+		// Leave invalid rules for validation to report.
+		if len(s.Names) == 0 {
+			out = append(out, s)
+			continue
+		}
+		if dropDefault && c.DefaultAction != "" && s.Action == c.DefaultAction &&
+			equality.Semantic.DeepEqual(s.ErrnoRet, c.DefaultErrnoRet) {
+			continue
+		}
+
+		// Empty args and filters are the same as none.
+		if len(s.Args) == 0 {
+			s.Args = nil
+		}
+		if s.Includes != nil && equality.Semantic.DeepEqual(*s.Includes, SeccompProfileFilter{}) {
+			s.Includes = nil
+		}
+		if s.Excludes != nil && equality.Semantic.DeepEqual(*s.Excludes, SeccompProfileFilter{}) {
+			s.Excludes = nil
+		}
+
+		merged := false
+		for i := range out {
+			if len(out[i].Names) != 0 && sameRule(out[i], s) {
+				out[i].Names = append(out[i].Names, s.Names...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			out = append(out, s)
+		}
+	}
+	for i := range out {
+		if len(out[i].Names) != 0 {
+			out[i].Names = sets.NewString(out[i].Names...).List()
+		}
+	}
+	c.Syscalls = out
+}
+
+// sameRule returns true if the rules differ only in their syscall names.
+func sameRule(a, b SeccompProfileSyscall) bool {
+	a.Names, b.Names = nil, nil
+	return equality.Semantic.DeepEqual(a, b)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+)
+
+func TestSetDefaults(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		in   SeccompProfileSpec
+		want SeccompProfileSpec
+	}{{
+		desc: "sort and dedupe names",
+		in: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"write", "read", "write"},
+				Action: ActionAllow,
+			}},
+		}},
+		want: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"read", "write"},
+				Action: ActionAllow,
+			}},
+		}},
+	}, {
+		desc: "merge identical rules",
+		in: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"write"},
+				Action: ActionAllow,
+			}, {
+				Names:    []string{"ptrace"},
+				Action:   ActionErr,
+				ErrnoRet: pointer.Uint(1),
+			}, {
+				Names:  []string{"read"},
+				Action: ActionAllow,
+				Args:   []SeccompProfileArg{},
+			}, {
+				Names:  []string{"personality"},
+				Action: ActionAllow,
+				Args:   []SeccompProfileArg{{Index: 0, Value: 8, Op: OpEqualTo}},
+			}},
+		}},
+		want: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"read", "write"},
+				Action: ActionAllow,
+			}, {
+				Names:    []string{"ptrace"},
+				Action:   ActionErr,
+				ErrnoRet: pointer.Uint(1),
+			}, {
+				Names:  []string{"personality"},
+				Action: ActionAllow,
+				Args:   []SeccompProfileArg{{Index: 0, Value: 8, Op: OpEqualTo}},
+			}},
+		}},
+	}, {
+		desc: "keep rules repeating the default action",
+		in: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"read"},
+				Action: ActionAllow,
+			}, {
+				Names:  []string{"mount"},
+				Action: ActionErr,
+			}},
+		}},
+		want: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"read"},
+				Action: ActionAllow,
+			}, {
+				Names:  []string{"mount"},
+				Action: ActionErr,
+			}},
+		}},
+	}, {
+		desc: "keep rules repeating the default action when extending a profile",
+		in: SeccompProfileSpec{
+			BaseProfileRef: &BaseProfileReference{Name: "baseline"},
+			RemoveSyscalls: []string{"ptrace", "mount", "ptrace"},
+			Contents: &SeccompProfileJSON{
+				DefaultAction: ActionErr,
+				Syscalls: []SeccompProfileSyscall{{
+					Names:  []string{"unshare"},
+					Action: ActionErr,
+				}},
+			},
+		},
+		want: SeccompProfileSpec{
+			BaseProfileRef: &BaseProfileReference{Name: "baseline"},
+			RemoveSyscalls: []string{"mount", "ptrace"},
+			Contents: &SeccompProfileJSON{
+				DefaultAction: ActionErr,
+				Syscalls: []SeccompProfileSyscall{{
+					Names:  []string{"unshare"},
+					Action: ActionErr,
+				}},
+			},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got := c.in.DeepCopy()
+			got.SetDefaults(context.Background())
+			if diff := cmp.Diff(&c.want, got); diff != "" {
+				t.Errorf("SetDefaults() (-want +got): %s", diff)
+			}
+
+			// Defaulting is idempotent.
+			again := got.DeepCopy()
+			again.SetDefaults(context.Background())
+			if diff := cmp.Diff(got, again); diff != "" {
+				t.Errorf("SetDefaults() twice (-once +twice): %s", diff)
+			}
+		})
+	}
+}
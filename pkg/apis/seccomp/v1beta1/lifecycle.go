@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+var condSet = apis.NewLivingConditionSet()
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (sp *SeccompProfile) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("SeccompProfile")
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (sp *SeccompProfile) GetConditionSet() apis.ConditionSet {
+	return condSet
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (sp *NamespacedSeccompProfile) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("NamespacedSeccompProfile")
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (sp *NamespacedSeccompProfile) GetConditionSet() apis.ConditionSet {
+	return condSet
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (ns *SeccompProfileNodeStatus) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("SeccompProfileNodeStatus")
+}
+
+const (
+	// ReasonInvalidContents is the reason a profile isn't ready when its
+	// contents, merged with those of any base profiles, are invalid.
+	ReasonInvalidContents = "InvalidContents"
+
+	// ReasonWriteFailed is the reason a profile isn't ready when it couldn't
+	// be written to some of the nodes.
+	ReasonWriteFailed = "WriteFailed"
+
+	// ReasonWriting is the reason a profile isn't ready yet while it hasn't
+	// been written to every node.
+	ReasonWriting = "Writing"
+)
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *SeccompProfileStatus) InitializeConditions() {
+	condSet.Manage(status).InitializeConditions()
+}
+
+// MarkReady records that the profile was written, with the hash of its
+// contents and the path pods use to reference it.
+func (status *SeccompProfileStatus) MarkReady(contentHash, localhostProfile string) {
+	status.ContentHash = contentHash
+	status.LocalhostProfile = localhostProfile
+	condSet.Manage(status).MarkTrue(apis.ConditionReady)
+}
+
+// MarkInvalidContents records that the profile's contents are invalid.
+func (status *SeccompProfileStatus) MarkInvalidContents(messageFormat string, messageA ...interface{}) {
+	condSet.Manage(status).MarkFalse(apis.ConditionReady, ReasonInvalidContents, messageFormat, messageA...)
+}
+
+// MarkWriteFailed records that the profile couldn't be written.
+func (status *SeccompProfileStatus) MarkWriteFailed(messageFormat string, messageA ...interface{}) {
+	condSet.Manage(status).MarkFalse(apis.ConditionReady, ReasonWriteFailed, messageFormat, messageA...)
+}
+
+// PropagateDistribution records the hash of the profile's contents, the
+// path pods use to reference it, and which nodes have written it. The
+// profile is ready once every node has written its current contents.
+//
+// Every node reconciles every profile, so readiness is derived from the
+// statuses of all the nodes rather than the result of writing it locally,
+// so that each node reaches the same result.
+func (status *SeccompProfileStatus) PropagateDistribution(contentHash, localhostProfile string, d *SeccompProfileDistribution) {
+	status.Distribution = d
+	switch {
+	case d.Failed != 0:
+		status.ContentHash = contentHash
+		status.LocalhostProfile = localhostProfile
+		status.MarkWriteFailed("%d of %d nodes failed to write the profile", d.Failed, d.Nodes)
+	case d.Nodes == 0 || d.Written < d.Nodes:
+		status.ContentHash = contentHash
+		status.LocalhostProfile = localhostProfile
+		condSet.Manage(status).MarkUnknown(apis.ConditionReady, ReasonWriting, "Written to %s nodes", d.Summary)
+	default:
+		status.MarkReady(contentHash, localhostProfile)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestStatusLifecycle(t *testing.T) {
+	s := &SeccompProfileStatus{}
+	s.InitializeConditions()
+	if c := s.GetCondition(apis.ConditionReady); c == nil || c.Status != corev1.ConditionUnknown {
+		t.Fatalf("Ready = %v, want Unknown", c)
+	}
+
+	s.MarkInvalidContents("bad %s", "action")
+	if c := s.GetCondition(apis.ConditionReady); c.Status != corev1.ConditionFalse || c.Reason != ReasonInvalidContents || c.Message != "bad action" {
+		t.Errorf("Ready = %+v, want False/%s", c, ReasonInvalidContents)
+	}
+
+	s.MarkReady("sha256:abc", "profiles/p.json")
+	if c := s.GetCondition(apis.ConditionReady); c.Status != corev1.ConditionTrue {
+		t.Errorf("Ready = %+v, want True", c)
+	}
+	if s.ContentHash != "sha256:abc" || s.LocalhostProfile != "profiles/p.json" {
+		t.Errorf("status = %+v", s)
+	}
+
+	s.MarkWriteFailed("disk full")
+	if c := s.GetCondition(apis.ConditionReady); c.Status != corev1.ConditionFalse || c.Reason != ReasonWriteFailed {
+		t.Errorf("Ready = %+v, want False/%s", c, ReasonWriteFailed)
+	}
+}
+
+func TestPropagateDistribution(t *testing.T) {
+	for _, c := range []struct {
+		desc       string
+		d          SeccompProfileDistribution
+		want       corev1.ConditionStatus
+		wantReason string
+	}{{
+		desc: "written to every node",
+		d:    SeccompProfileDistribution{Nodes: 2, Written: 2, Summary: "2/2"},
+		want: corev1.ConditionTrue,
+	}, {
+		desc:       "written to some nodes",
+		d:          SeccompProfileDistribution{Nodes: 2, Written: 1, Summary: "1/2"},
+		want:       corev1.ConditionUnknown,
+		wantReason: ReasonWriting,
+	}, {
+		desc:       "no nodes",
+		d:          SeccompProfileDistribution{Summary: "0/0"},
+		want:       corev1.ConditionUnknown,
+		wantReason: ReasonWriting,
+	}, {
+		desc:       "failed on a node",
+		d:          SeccompProfileDistribution{Nodes: 2, Written: 1, Failed: 1, Summary: "1/2"},
+		want:       corev1.ConditionFalse,
+		wantReason: ReasonWriteFailed,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			s := &SeccompProfileStatus{}
+			s.InitializeConditions()
+			s.PropagateDistribution("sha256:abc", "profiles/p.json", &c.d)
+			cond := s.GetCondition(apis.ConditionReady)
+			if cond.Status != c.want || cond.Reason != c.wantReason {
+				t.Errorf("Ready = %+v, want %s/%s", cond, c.want, c.wantReason)
+			}
+			if s.ContentHash != "sha256:abc" || s.LocalhostProfile != "profiles/p.json" || s.Distribution != &c.d {
+				t.Errorf("status = %+v", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"knative.dev/pkg/apis"
+)
+
+// blocks returns true if the action stops the syscall from running.
+func (a Action) blocks() bool {
+	switch a {
+	case ActionErr, ActionKill, ActionKillProcess, ActionKillThread, ActionTrap:
+		return true
+	default:
+		return false
+	}
+}
+
+// unconditional returns true if the rule applies to every call of its
+// syscalls.
+func (s SeccompProfileSyscall) unconditional() bool {
+	return len(s.Args) == 0 && s.Includes == nil && s.Excludes == nil
+}
+
+// requiredSyscalls are needed by every container.
+var requiredSyscalls = []string{"execve", "exit_group"}
+
+// lint returns warnings about valid profiles that probably don't do what
+// their authors meant. If the profile extends another, checks that depend
+// on the whole profile are skipped.
+func (c *SeccompProfileJSON) lint(extends bool) *apis.FieldError {
+	var errs *apis.FieldError
+	warn := func(field, msg string) {
+		errs = errs.Also(apis.ErrGeneric(msg, field).At(apis.WarningLevel))
+	}
+
+	first := map[string]int{}
+	for i, s := range c.Syscalls {
+		if !extends && s.Action == c.DefaultAction && equality.Semantic.DeepEqual(s.ErrnoRet, c.DefaultErrnoRet) {
+			warn("contents.syscalls.action", fmt.Sprintf("item %d: %s is also the default action, so this rule has no effect", i, s.Action))
+		}
+		if s.Action == ActionErr && s.ErrnoRet != nil && *s.ErrnoRet == 0 {
+			warn("contents.syscalls.errnoRet", fmt.Sprintf("item %d: errnoRet 0 makes syscalls appear to succeed without running", i))
+		}
+		if !s.unconditional() {
+			continue
+		}
+		for _, n := range s.Names {
+			j, ok := first[n]
+			if !ok {
+				first[n] = i
+			} else if other := c.Syscalls[j].Action; other != s.Action {
+				warn("contents.syscalls.names", fmt.Sprintf("item %d: %s is also listed in item %d, with conflicting action %s", i, n, j, other))
+			}
+		}
+	}
+	if c.DefaultErrnoRet != nil && *c.DefaultErrnoRet == 0 {
+		warn("contents.defaultErrnoRet", "defaultErrnoRet 0 makes syscalls appear to succeed without running")
+	}
+	if extends {
+		return errs
+	}
+
+	for _, n := range requiredSyscalls {
+		if c.blocked(n) {
+			warn("contents.syscalls", fmt.Sprintf("%s is blocked, so no container can run with this profile", n))
+		}
+	}
+	if len(c.AllArchitectures()) == 0 && c.DefaultAction == ActionErr {
+		warn("contents.architectures", "no architectures are listed, so only the node's native syscall ABI is allowed; processes using others, such as 32-bit x86 on x86_64, are killed")
+	}
+	return errs
+}
+
+// blocked returns true if the syscall can never run.
+func (c *SeccompProfileJSON) blocked(name string) bool {
+	action := c.DefaultAction
+	found := false
+	for _, s := range c.Syscalls {
+		for _, n := range s.Names {
+			if n != name {
+				continue
+			}
+			if !s.unconditional() && !s.Action.blocks() {
+				// It can run under some conditions.
+				return false
+			}
+			if s.unconditional() && !found {
+				action, found = s.Action, true
+			}
+		}
+	}
+	return action.blocks()
+}
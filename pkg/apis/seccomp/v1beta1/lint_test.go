@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/utils/pointer"
+	"knative.dev/pkg/apis"
+)
+
+func TestLint(t *testing.T) {
+	arches := []string{"SCMP_ARCH_X86_64"}
+	required := SeccompProfileSyscall{Names: []string{"execve", "exit_group"}, Action: ActionAllow}
+
+	for _, c := range []struct {
+		desc string
+		spec SeccompProfileSpec
+		want []string
+	}{{
+		desc: "clean",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: arches,
+			Syscalls:      []SeccompProfileSyscall{required},
+		}},
+	}, {
+		desc: "conflicting actions",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: arches,
+			Syscalls: []SeccompProfileSyscall{required, {
+				Names:  []string{"ptrace"},
+				Action: ActionAllow,
+			}, {
+				Names:  []string{"ptrace"},
+				Action: ActionKillProcess,
+			}},
+		}},
+		want: []string{"ptrace is also listed in item 0"},
+	}, {
+		desc: "allow under allow default",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"read"},
+				Action: ActionAllow,
+			}},
+		}},
+		want: []string{"this rule has no effect"},
+	}, {
+		desc: "allow under allow default when extending a profile",
+		spec: SeccompProfileSpec{
+			BaseProfileRef: &BaseProfileReference{Name: "baseline"},
+			Contents: &SeccompProfileJSON{
+				DefaultAction: ActionAllow,
+				Syscalls: []SeccompProfileSyscall{{
+					Names:  []string{"read"},
+					Action: ActionAllow,
+				}},
+			},
+		},
+	}, {
+		desc: "execve blocked",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: arches,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"exit_group"},
+				Action: ActionAllow,
+			}},
+		}},
+		want: []string{"execve is blocked"},
+	}, {
+		desc: "execve allowed with conditions",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: arches,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"exit_group"},
+				Action: ActionAllow,
+			}, {
+				Names:    []string{"execve"},
+				Action:   ActionAllow,
+				Includes: &SeccompProfileFilter{Caps: []string{"CAP_SYS_ADMIN"}},
+			}},
+		}},
+	}, {
+		desc: "no architectures with errno default",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{required},
+		}},
+		want: []string{"no architectures are listed"},
+	}, {
+		desc: "errnoRet 0",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: arches,
+			Syscalls: []SeccompProfileSyscall{required, {
+				Names:    []string{"mount"},
+				Action:   ActionErr,
+				ErrnoRet: pointer.Uint(0),
+			}},
+		}},
+		want: []string{"errnoRet 0"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			// Profiles are defaulted before they're validated on admission.
+			spec := c.spec.DeepCopy()
+			spec.SetDefaults(context.Background())
+			err := spec.Validate(context.Background())
+			if errs := err.Filter(apis.ErrorLevel); errs != nil {
+				t.Fatalf("Validate() = %v", errs)
+			}
+			warnings := err.Filter(apis.WarningLevel)
+			if len(c.want) == 0 {
+				if warnings != nil {
+					t.Errorf("Validate() warnings = %v, want none", warnings)
+				}
+				return
+			}
+			if warnings == nil {
+				t.Fatalf("Validate() returned no warnings, want %q", c.want)
+			}
+			for _, w := range c.want {
+				if !strings.Contains(warnings.Error(), w) {
+					t.Errorf("Validate() warnings = %v, want %q", warnings, w)
+				}
+			}
+		})
+	}
+}
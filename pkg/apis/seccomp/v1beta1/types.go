@@ -0,0 +1,355 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// SeccompProfile represents a seccomp profile to distribute to nodes.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SeccompProfile struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the SeccompProfile (from the client).
+	// +optional
+	Spec SeccompProfileSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the SeccompProfile.
+	// +optional
+	Status SeccompProfileStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that SeccompProfile can be validated, defaulted and converted.
+	_ apis.Validatable   = (*SeccompProfile)(nil)
+	_ apis.Defaultable   = (*SeccompProfile)(nil)
+	_ apis.Convertible   = (*SeccompProfile)(nil)
+	_ kmeta.OwnerRefable = (*SeccompProfile)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*SeccompProfile)(nil)
+)
+
+// NamespacedSeccompProfile represents a seccomp profile owned by a
+// namespace, to distribute to nodes alongside cluster-wide SeccompProfiles.
+//
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespacedSeccompProfile struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the NamespacedSeccompProfile (from the client).
+	// +optional
+	Spec SeccompProfileSpec `json:"spec,omitempty"`
+
+	// Status communicates the observed state of the NamespacedSeccompProfile.
+	// +optional
+	Status SeccompProfileStatus `json:"status,omitempty"`
+}
+
+var (
+	// Check that NamespacedSeccompProfile can be validated and defaulted.
+	_ apis.Validatable   = (*NamespacedSeccompProfile)(nil)
+	_ apis.Defaultable   = (*NamespacedSeccompProfile)(nil)
+	_ kmeta.OwnerRefable = (*NamespacedSeccompProfile)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*NamespacedSeccompProfile)(nil)
+)
+
+// SeccompProfileSpec holds the desired state of the SeccompProfileSpec (from the client).
+type SeccompProfileSpec struct {
+	// BaseProfileRef names a SeccompProfile or a builtin profile that this
+	// profile extends. The base profile's syscall rules are merged with those
+	// in Contents, and Contents' other fields override the base profile's
+	// when set.
+	// +optional
+	BaseProfileRef *BaseProfileReference `json:"baseProfileRef,omitempty"`
+
+	// RemoveSyscalls are syscalls to remove from the base profile's rules.
+	// +optional
+	RemoveSyscalls []string `json:"removeSyscalls,omitempty"`
+
+	// TranslateSyscalls adds the equivalents of syscalls that don't exist on
+	// some of the profile's architectures, such as openat for open on arm64,
+	// so that a profile recorded on one architecture works on the others.
+	// +optional
+	TranslateSyscalls bool `json:"translateSyscalls,omitempty"`
+
+	// Contents contains the contents of the policy as JSON. It is required
+	// unless BaseProfileRef is set.
+	// +optional
+	Contents *SeccompProfileJSON `json:"contents,omitempty"`
+}
+
+// BaseProfileReference refers to a profile to extend. Exactly one of Name
+// or Builtin must be set.
+type BaseProfileReference struct {
+	// Name is the name of the cluster-wide SeccompProfile.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Builtin is the name of a profile shipped with the controller, such
+	// as RuntimeDefault.
+	// +optional
+	Builtin BuiltinProfile `json:"builtin,omitempty"`
+}
+
+// BuiltinProfile is the name of a profile shipped with the controller.
+type BuiltinProfile string
+
+// BuiltinRuntimeDefault is a copy of the default profile applied by Docker
+// and containerd to containers with a RuntimeDefault seccomp profile.
+const BuiltinRuntimeDefault BuiltinProfile = "RuntimeDefault"
+
+// SeccompProfileJSON is a seccomp profile, in the format read by container
+// runtimes.
+type SeccompProfileJSON struct {
+	DefaultAction Action `json:"defaultAction"`
+	// DefaultErrnoRet is the errno returned by the default action, if it is
+	// SCMP_ACT_ERRNO or SCMP_ACT_TRACE.
+	// +optional
+	DefaultErrnoRet *uint    `json:"defaultErrnoRet,omitempty"`
+	Architectures   []string `json:"architectures,omitempty"`
+	// ArchMap is the Docker-format alternative to Architectures, listing
+	// each main architecture along with its sub-architectures.
+	// +optional
+	ArchMap  []SeccompProfileArchMap `json:"archMap,omitempty"`
+	Syscalls []SeccompProfileSyscall `json:"syscalls,omitempty"`
+	// Flags are passed to the seccomp(2) syscall when the filter is loaded.
+	// +optional
+	Flags []Flag `json:"flags,omitempty"`
+	// ListenerPath is the path of a UNIX socket the runtime will pass the
+	// seccomp notify file descriptor to. It is required to use
+	// SCMP_ACT_NOTIFY.
+	// +optional
+	ListenerPath string `json:"listenerPath,omitempty"`
+	// ListenerMetadata is opaque data passed to the seccomp agent listening
+	// on ListenerPath.
+	// +optional
+	ListenerMetadata string `json:"listenerMetadata,omitempty"`
+}
+
+// SeccompProfileArchMap maps a main architecture to the sub-architectures
+// that should be allowed alongside it.
+type SeccompProfileArchMap struct {
+	Architecture     string   `json:"architecture"`
+	SubArchitectures []string `json:"subArchitectures,omitempty"`
+}
+
+// AllArchitectures returns the architectures the profile applies to, from
+// either Architectures or the flattened ArchMap.
+func (c *SeccompProfileJSON) AllArchitectures() []string {
+	if len(c.ArchMap) == 0 {
+		return c.Architectures
+	}
+	var out []string
+	for _, am := range c.ArchMap {
+		out = append(out, am.Architecture)
+		out = append(out, am.SubArchitectures...)
+	}
+	return out
+}
+
+// SeccompProfileSyscall is a rule applying an action to one or more
+// syscalls.
+type SeccompProfileSyscall struct {
+	// Names are the syscalls the rule applies to.
+	Names  []string            `json:"names"`
+	Action Action              `json:"action"`
+	Args   []SeccompProfileArg `json:"args,omitempty"`
+	// ErrnoRet is the errno returned by the action, if it is SCMP_ACT_ERRNO
+	// or SCMP_ACT_TRACE.
+	// +optional
+	ErrnoRet *uint `json:"errnoRet,omitempty"`
+	// Includes limits the rule to nodes and containers matching the filter.
+	// +optional
+	Includes *SeccompProfileFilter `json:"includes,omitempty"`
+	// Excludes skips the rule on nodes and containers matching the filter.
+	// +optional
+	Excludes *SeccompProfileFilter `json:"excludes,omitempty"`
+}
+
+// SeccompProfileFilter conditionally applies a syscall rule, in the same
+// shape as Docker's default profile.
+//
+// Filters are resolved by the controller on each node before the profile
+// is written, since container runtimes don't all support them. Caps are
+// matched against the capabilities containers get by default.
+type SeccompProfileFilter struct {
+	// Arches are architectures, using Go's GOARCH names (plus "x86" and
+	// "x32"), that the filter matches.
+	// +optional
+	Arches []string `json:"arches,omitempty"`
+	// Caps are capabilities, such as CAP_SYS_ADMIN, that the filter matches.
+	// +optional
+	Caps []string `json:"caps,omitempty"`
+	// MinKernel is the minimum kernel version, as "<major>.<minor>", that
+	// the filter matches.
+	// +optional
+	MinKernel string `json:"minKernel,omitempty"`
+}
+
+// SeccompProfileArg filters a syscall based on the value of one of its
+// arguments, in the same shape used by OCI runtimes and Docker.
+type SeccompProfileArg struct {
+	// Index is the zero-based index of the syscall argument to compare.
+	Index uint `json:"index"`
+	// Value is the value to compare the argument against.
+	Value uint64 `json:"value"`
+	// ValueTwo is the second value used by SCMP_CMP_MASKED_EQ, where Value
+	// is the mask.
+	// +optional
+	ValueTwo uint64 `json:"valueTwo,omitempty"`
+	// Op is the comparison operator.
+	Op Operator `json:"op"`
+}
+
+// SeccompProfileStatus communicates the observed state of the SeccompProfile (from the controller).
+type SeccompProfileStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// ContentHash is the SHA-256 hash of the profile's contents, after any
+	// base profiles are merged in, as "sha256:<hex>". Nodes write these
+	// contents with conditional rules resolved for the node.
+	// +optional
+	ContentHash string `json:"contentHash,omitempty"`
+
+	// LocalhostProfile is the path pods use to reference the profile, as the
+	// localhostProfile of a Localhost seccompProfile.
+	// +optional
+	LocalhostProfile string `json:"localhostProfile,omitempty"`
+
+	// Distribution summarizes which nodes the profile's current contents
+	// have been written to.
+	// +optional
+	Distribution *SeccompProfileDistribution `json:"distribution,omitempty"`
+
+	// TranslatedSyscalls lists the syscalls added by TranslateSyscalls, each
+	// as "<added> (for <original>)".
+	// +optional
+	TranslatedSyscalls []string `json:"translatedSyscalls,omitempty"`
+}
+
+// SeccompProfileDistribution summarizes the SeccompProfileNodeStatuses of a
+// profile.
+type SeccompProfileDistribution struct {
+	// Nodes is the number of nodes the controller runs on.
+	Nodes int32 `json:"nodes"`
+
+	// Written is the number of nodes that have written the profile's
+	// current contents.
+	Written int32 `json:"written"`
+
+	// Failed is the number of nodes that failed to write the profile's
+	// current contents.
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// Summary is Written and Nodes as "N/M".
+	Summary string `json:"summary"`
+}
+
+// GetStatus retrieves the status of the resource. Implements the KRShaped interface.
+func (sp *SeccompProfile) GetStatus() *duckv1.Status {
+	return &sp.Status.Status
+}
+
+// SeccompProfileList is a list of SeccompProfile resources
+//
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SeccompProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SeccompProfile `json:"items"`
+}
+
+// GetStatus retrieves the status of the resource. Implements the KRShaped interface.
+func (sp *NamespacedSeccompProfile) GetStatus() *duckv1.Status {
+	return &sp.Status.Status
+}
+
+// NamespacedSeccompProfileList is a list of NamespacedSeccompProfile resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NamespacedSeccompProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []NamespacedSeccompProfile `json:"items"`
+}
+
+// SeccompProfileNodeStatus records the result of writing a profile to a
+// node. Each node writes one for each profile, in the profile's namespace,
+// or the system namespace for cluster-wide profiles.
+//
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SeccompProfileNodeStatus struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// NodeName is the name of the node the profile was written to.
+	NodeName string `json:"nodeName"`
+
+	// ProfileKind is the kind of the profile, either SeccompProfile or
+	// NamespacedSeccompProfile.
+	ProfileKind string `json:"profileKind"`
+
+	// ProfileName is the name of the profile.
+	ProfileName string `json:"profileName"`
+
+	// ContentHash is the hash of the contents the node last tried to write,
+	// as in the profile's status.
+	ContentHash string `json:"contentHash"`
+
+	// WriteTime is when the node last tried to write the profile.
+	WriteTime metav1.Time `json:"writeTime"`
+
+	// Error is why the node failed to write the profile, if it did.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ProfileUIDLabel is set on SeccompProfileNodeStatuses to the UID of their
+// profile.
+const ProfileUIDLabel = "seccomp.imjasonh.dev/profile-uid"
+
+// SeccompProfileNodeStatusList is a list of SeccompProfileNodeStatus
+// resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SeccompProfileNodeStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SeccompProfileNodeStatus `json:"items"`
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// strictness orders actions the way libseccomp does when more than one
+// rule matches a syscall: the highest wins.
+var strictness = map[Action]int{
+	ActionAllow:       0,
+	ActionLog:         1,
+	ActionTrace:       2,
+	ActionNotify:      3,
+	ActionErr:         4,
+	ActionTrap:        5,
+	ActionKill:        6,
+	ActionKillThread:  6,
+	ActionKillProcess: 7,
+}
+
+// verdict is an action along with the errno it returns.
+type verdict struct {
+	action   Action
+	errnoRet *uint
+}
+
+func (v verdict) looser(o verdict) bool {
+	return strictness[v.action] < strictness[o.action]
+}
+
+// loosest returns the loosest of the verdicts, preferring the first of
+// equally strict ones.
+func loosest(vs ...verdict) verdict {
+	out := vs[0]
+	for _, v := range vs[1:] {
+		if v.looser(out) {
+			out = v
+		}
+	}
+	return out
+}
+
+// syscallRules are one profile's rules for a syscall.
+type syscallRules struct {
+	// base is what the profile does to calls that no conditional rule
+	// overrides: the strictest unconditional rule, or the default action.
+	base verdict
+	// unconditional is true if base comes from a rule.
+	unconditional bool
+	// conditional are the rules with args or filters that can take
+	// effect. Rules looser than an unconditional rule never do, since the
+	// strictest matching rule wins.
+	conditional []SeccompProfileSyscall
+}
+
+func rulesFor(p *SeccompProfileJSON, name string) syscallRules {
+	out := syscallRules{base: verdict{p.DefaultAction, p.DefaultErrnoRet}}
+	var conditional []SeccompProfileSyscall
+	for _, s := range p.Syscalls {
+		if !sets.NewString(s.Names...).Has(name) {
+			continue
+		}
+		v := verdict{s.Action, s.ErrnoRet}
+		switch {
+		case !s.unconditional():
+			conditional = append(conditional, s)
+		case !out.unconditional, out.base.looser(v):
+			out.base, out.unconditional = v, true
+		}
+	}
+	for _, s := range conditional {
+		if !out.unconditional || out.base.looser(verdict{s.Action, s.ErrnoRet}) {
+			s.Names = []string{name}
+			out.conditional = append(out.conditional, s)
+		}
+	}
+	return out
+}
+
+// Union returns a profile that allows every syscall that any of the
+// profiles allows, for pods whose containers each need one of them.
+//
+// Architectures, sub-architectures and flags are merged. The default
+// action is the loosest of the profiles' default actions, and each syscall
+// gets the loosest of the actions the profiles would take on it. Where the
+// profiles' conditional rules can't be combined exactly, the union errs on
+// the side of allowing more, so that no container is broken by it.
+func Union(profiles ...*SeccompProfileJSON) (*SeccompProfileJSON, error) {
+	if len(profiles) == 0 {
+		return nil, errors.New("no profiles to union")
+	}
+	out := &SeccompProfileJSON{}
+
+	defaults := make([]verdict, 0, len(profiles))
+	names := sets.NewString()
+	flags := sets.NewString()
+	for i, p := range profiles {
+		if p.DefaultAction == "" {
+			return nil, fmt.Errorf("profile %d has no default action", i)
+		}
+		defaults = append(defaults, verdict{p.DefaultAction, p.DefaultErrnoRet})
+		for _, s := range p.Syscalls {
+			names.Insert(s.Names...)
+		}
+		for _, f := range p.Flags {
+			flags.Insert(string(f))
+		}
+		if p.ListenerPath != "" {
+			if out.ListenerPath != "" && (out.ListenerPath != p.ListenerPath || out.ListenerMetadata != p.ListenerMetadata) {
+				return nil, fmt.Errorf("profiles have different listeners %q and %q", out.ListenerPath, p.ListenerPath)
+			}
+			out.ListenerPath, out.ListenerMetadata = p.ListenerPath, p.ListenerMetadata
+		}
+	}
+	def := loosest(defaults...)
+	out.DefaultAction, out.DefaultErrnoRet = def.action, def.errnoRet
+	for _, f := range flags.List() {
+		out.Flags = append(out.Flags, Flag(f))
+	}
+	out.Architectures, out.ArchMap = unionArchitectures(profiles)
+
+	for _, name := range names.List() {
+		out.Syscalls = append(out.Syscalls, unionSyscall(profiles, name, def)...)
+	}
+	out.canonicalize(true)
+	return out, nil
+}
+
+// unionSyscall returns the rules for a syscall in the union of the
+// profiles, whose default action is def.
+func unionSyscall(profiles []*SeccompProfileJSON, name string, def verdict) []SeccompProfileSyscall {
+	rules := make([]syscallRules, 0, len(profiles))
+	bases := make([]verdict, 0, len(profiles))
+	for _, p := range profiles {
+		r := rulesFor(p, name)
+		rules = append(rules, r)
+		bases = append(bases, r.base)
+	}
+	base := loosest(bases...)
+	rule := func(v verdict) SeccompProfileSyscall {
+		return SeccompProfileSyscall{Names: []string{name}, Action: v.action, ErrnoRet: v.errnoRet}
+	}
+
+	// Conditional rules looser than base allow calls that some profile
+	// allows. They can only take effect without an unconditional rule,
+	// so calls they don't match get the default action instead of base.
+	var looser []SeccompProfileSyscall
+	for _, r := range rules {
+		for _, s := range r.conditional {
+			if (verdict{s.Action, s.ErrnoRet}).looser(base) {
+				looser = append(looser, s)
+			}
+		}
+	}
+	if len(looser) != 0 {
+		if !base.looser(def) {
+			return looser
+		}
+		// The default is stricter than base, so apply the loosest action
+		// to every call instead.
+		vs := []verdict{base}
+		for _, s := range looser {
+			vs = append(vs, verdict{s.Action, s.ErrnoRet})
+		}
+		return []SeccompProfileSyscall{rule(loosest(vs...))}
+	}
+
+	out := []SeccompProfileSyscall{rule(base)}
+	// A stricter conditional rule is kept only if every other profile is
+	// at least as strict on every call of the syscall.
+	for i, r := range rules {
+		for _, s := range r.conditional {
+			v := verdict{s.Action, s.ErrnoRet}
+			if !base.looser(v) {
+				continue
+			}
+			keep := true
+			for j, o := range rules {
+				if j != i && (len(o.conditional) != 0 || o.base.looser(v)) {
+					keep = false
+					break
+				}
+			}
+			if keep {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// unionArchitectures merges the profiles' architectures. If any profile
+// uses ArchMap, the result does too, with the main architectures of the
+// others that aren't already included.
+func unionArchitectures(profiles []*SeccompProfileJSON) ([]string, []SeccompProfileArchMap) {
+	subs := map[string]sets.String{}
+	all := sets.NewString()
+	for _, p := range profiles {
+		for _, am := range p.ArchMap {
+			if _, ok := subs[am.Architecture]; !ok {
+				subs[am.Architecture] = sets.NewString()
+			}
+			subs[am.Architecture].Insert(am.SubArchitectures...)
+			all.Insert(am.Architecture)
+			all.Insert(am.SubArchitectures...)
+		}
+	}
+
+	arches := sets.NewString()
+	for _, p := range profiles {
+		if len(p.ArchMap) == 0 {
+			arches.Insert(p.Architectures...)
+		}
+	}
+	if len(subs) == 0 {
+		if arches.Len() == 0 {
+			return nil, nil
+		}
+		return arches.List(), nil
+	}
+
+	for _, a := range arches.List() {
+		if !all.Has(a) {
+			subs[a] = sets.NewString()
+		}
+	}
+	out := make([]SeccompProfileArchMap, 0, len(subs))
+	for _, a := range sets.StringKeySet(subs).List() {
+		am := SeccompProfileArchMap{Architecture: a}
+		if subs[a].Len() != 0 {
+			am.SubArchitectures = subs[a].List()
+		}
+		out = append(out, am)
+	}
+	return nil, out
+}
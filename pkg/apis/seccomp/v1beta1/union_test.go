@@ -0,0 +1,240 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/pointer"
+)
+
+func TestUnion(t *testing.T) {
+	personality := []SeccompProfileArg{{Index: 0, Value: 8, Op: OpEqualTo}}
+
+	for _, c := range []struct {
+		desc    string
+		in      []*SeccompProfileJSON
+		want    *SeccompProfileJSON
+		wantErr bool
+	}{{
+		desc: "allowlists",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"read", "exit_group"}, Action: ActionAllow}},
+		}, {
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"write", "exit_group"}, Action: ActionAllow}},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"exit_group", "read", "write"}, Action: ActionAllow}},
+		},
+	}, {
+		desc: "loosest default action",
+		in: []*SeccompProfileJSON{{
+			DefaultAction:   ActionErr,
+			DefaultErrnoRet: pointer.Uint(1),
+			Syscalls:        []SeccompProfileSyscall{{Names: []string{"read"}, Action: ActionAllow}},
+		}, {
+			DefaultAction: ActionAllow,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"ptrace"}, Action: ActionKillProcess}},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"ptrace"}, Action: ActionErr, ErrnoRet: pointer.Uint(1)}},
+		},
+	}, {
+		desc: "loosest of unconditional rules",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionAllow,
+			Syscalls: []SeccompProfileSyscall{
+				{Names: []string{"ptrace"}, Action: ActionKillProcess},
+				{Names: []string{"mount"}, Action: ActionTrap},
+			},
+		}, {
+			DefaultAction: ActionAllow,
+			Syscalls: []SeccompProfileSyscall{
+				{Names: []string{"ptrace", "mount"}, Action: ActionKillThread},
+			},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			Syscalls: []SeccompProfileSyscall{
+				{Names: []string{"mount"}, Action: ActionTrap},
+				{Names: []string{"ptrace"}, Action: ActionKillThread},
+			},
+		},
+	}, {
+		desc: "looser conditional rule",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionAllow, Args: personality}},
+		}, {
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"read"}, Action: ActionAllow}},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{
+				{Names: []string{"personality"}, Action: ActionAllow, Args: personality},
+				{Names: []string{"read"}, Action: ActionAllow},
+			},
+		},
+	}, {
+		desc: "looser conditional rule widened over a stricter default",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionAllow, Args: personality}},
+		}, {
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionLog}},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionAllow}},
+		},
+	}, {
+		desc: "conditional rule shadowed by a stricter unconditional rule",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{
+				{Names: []string{"personality"}, Action: ActionAllow, Args: personality},
+				{Names: []string{"personality"}, Action: ActionKillProcess},
+			},
+		}, {
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionTrap}},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionTrap}},
+		},
+	}, {
+		desc: "stricter conditional rule kept",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionAllow,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionErr, Args: personality}},
+		}, {
+			DefaultAction: ActionAllow,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionKillProcess}},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionErr, Args: personality}},
+		},
+	}, {
+		desc: "stricter conditional rule dropped",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionAllow,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"personality"}, Action: ActionErr, Args: personality}},
+		}, {
+			DefaultAction: ActionAllow,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"ptrace"}, Action: ActionErr}},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+		},
+	}, {
+		desc: "architectures and flags",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_AARCH64"},
+			Flags:         []Flag{FlagLog},
+		}, {
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_X86"},
+			Flags:         []Flag{FlagSpecAllow, FlagLog},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_AARCH64", "SCMP_ARCH_X86", "SCMP_ARCH_X86_64"},
+			Flags:         []Flag{FlagLog, FlagSpecAllow},
+		},
+	}, {
+		desc: "arch map",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture:     "SCMP_ARCH_X86_64",
+				SubArchitectures: []string{"SCMP_ARCH_X86"},
+			}},
+		}, {
+			DefaultAction: ActionErr,
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture:     "SCMP_ARCH_X86_64",
+				SubArchitectures: []string{"SCMP_ARCH_X32"},
+			}},
+		}, {
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86", "SCMP_ARCH_AARCH64"},
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture: "SCMP_ARCH_AARCH64",
+			}, {
+				Architecture:     "SCMP_ARCH_X86_64",
+				SubArchitectures: []string{"SCMP_ARCH_X32", "SCMP_ARCH_X86"},
+			}},
+		},
+	}, {
+		desc: "same listener",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			ListenerPath:  "/run/agent.sock",
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"mount"}, Action: ActionNotify}},
+		}, {
+			DefaultAction: ActionErr,
+			ListenerPath:  "/run/agent.sock",
+		}},
+		want: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			ListenerPath:  "/run/agent.sock",
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"mount"}, Action: ActionNotify}},
+		},
+	}, {
+		desc: "different listeners",
+		in: []*SeccompProfileJSON{{
+			DefaultAction: ActionErr,
+			ListenerPath:  "/run/agent.sock",
+		}, {
+			DefaultAction: ActionErr,
+			ListenerPath:  "/run/other.sock",
+		}},
+		wantErr: true,
+	}, {
+		desc: "no default action",
+		in: []*SeccompProfileJSON{{
+			Syscalls: []SeccompProfileSyscall{{Names: []string{"read"}, Action: ActionAllow}},
+		}},
+		wantErr: true,
+	}, {
+		desc:    "no profiles",
+		wantErr: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got, err := Union(c.in...)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("Union() = %v, wantErr %t", err, c.wantErr)
+			}
+			if d := cmp.Diff(c.want, got); d != "" {
+				t.Errorf("Diff (-want,+got): %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+
+	"github.com/imjasonh/seccomp-profile/pkg/syscalls"
+)
+
+// SupportedVerbs returns the operations that validation should be called for.
+func (sp *SeccompProfile) SupportedVerbs() []admissionregistrationv1.OperationType {
+	// Don't validate on delete.
+	return []admissionregistrationv1.OperationType{
+		admissionregistrationv1.Create,
+		admissionregistrationv1.Update,
+	}
+}
+
+// Validate implements apis.Validatable
+func (sp *SeccompProfile) Validate(ctx context.Context) *apis.FieldError {
+	if ref := sp.Spec.BaseProfileRef; ref != nil && ref.Name == sp.Name {
+		return apis.ErrInvalidValue(ref.Name, "spec.baseProfileRef.name", "a profile cannot extend itself")
+	}
+	return sp.Spec.Validate(ctx).ViaField("spec")
+}
+
+// SupportedVerbs returns the operations that validation should be called for.
+func (sp *NamespacedSeccompProfile) SupportedVerbs() []admissionregistrationv1.OperationType {
+	// Don't validate on delete.
+	return []admissionregistrationv1.OperationType{
+		admissionregistrationv1.Create,
+		admissionregistrationv1.Update,
+	}
+}
+
+// Validate implements apis.Validatable
+func (sp *NamespacedSeccompProfile) Validate(ctx context.Context) *apis.FieldError {
+	return sp.Spec.Validate(ctx).ViaField("spec")
+}
+
+type Action string
+
+const (
+	ActionLog         Action = "SCMP_ACT_LOG"
+	ActionErr         Action = "SCMP_ACT_ERRNO"
+	ActionAllow       Action = "SCMP_ACT_ALLOW"
+	ActionKill        Action = "SCMP_ACT_KILL"
+	ActionKillProcess Action = "SCMP_ACT_KILL_PROCESS"
+	ActionKillThread  Action = "SCMP_ACT_KILL_THREAD"
+	ActionTrap        Action = "SCMP_ACT_TRAP"
+	ActionTrace       Action = "SCMP_ACT_TRACE"
+	ActionNotify      Action = "SCMP_ACT_NOTIFY"
+)
+
+func (a Action) Valid() error {
+	switch a {
+	case ActionLog, ActionErr, ActionAllow,
+		ActionKill, ActionKillProcess, ActionKillThread,
+		ActionTrap, ActionTrace, ActionNotify:
+		return nil
+	default:
+		return fmt.Errorf("unknown action: %s", a)
+	}
+}
+
+// acceptsErrnoRet returns true if the action can be paired with an errno
+// return code. SCMP_ACT_ERRNO returns it to the caller, and SCMP_ACT_TRACE
+// passes it to the tracer.
+func (a Action) acceptsErrnoRet() bool {
+	return a == ActionErr || a == ActionTrace
+}
+
+type Operator string
+
+const (
+	OpNotEqual     Operator = "SCMP_CMP_NE"
+	OpLessThan     Operator = "SCMP_CMP_LT"
+	OpLessEqual    Operator = "SCMP_CMP_LE"
+	OpEqualTo      Operator = "SCMP_CMP_EQ"
+	OpGreaterEqual Operator = "SCMP_CMP_GE"
+	OpGreaterThan  Operator = "SCMP_CMP_GT"
+	OpMaskedEqual  Operator = "SCMP_CMP_MASKED_EQ"
+)
+
+func (o Operator) Valid() error {
+	switch o {
+	case OpNotEqual, OpLessThan, OpLessEqual, OpEqualTo, OpGreaterEqual, OpGreaterThan, OpMaskedEqual:
+		return nil
+	default:
+		return fmt.Errorf("unknown operator: %s", o)
+	}
+}
+
+type Flag string
+
+const (
+	FlagLog              Flag = "SECCOMP_FILTER_FLAG_LOG"
+	FlagSpecAllow        Flag = "SECCOMP_FILTER_FLAG_SPEC_ALLOW"
+	FlagTSync            Flag = "SECCOMP_FILTER_FLAG_TSYNC"
+	FlagWaitKillableRecv Flag = "SECCOMP_FILTER_FLAG_WAIT_KILLABLE_RECV"
+)
+
+func (f Flag) Valid() error {
+	switch f {
+	case FlagLog, FlagSpecAllow, FlagTSync, FlagWaitKillableRecv:
+		return nil
+	default:
+		return fmt.Errorf("unknown flag: %s", f)
+	}
+}
+
+// knownArchitectures are the architecture names understood by libseccomp.
+var knownArchitectures = sets.NewString(
+	"SCMP_ARCH_X86",
+	"SCMP_ARCH_X86_64",
+	"SCMP_ARCH_X32",
+	"SCMP_ARCH_ARM",
+	"SCMP_ARCH_AARCH64",
+	"SCMP_ARCH_MIPS",
+	"SCMP_ARCH_MIPS64",
+	"SCMP_ARCH_MIPS64N32",
+	"SCMP_ARCH_MIPSEL",
+	"SCMP_ARCH_MIPSEL64",
+	"SCMP_ARCH_MIPSEL64N32",
+	"SCMP_ARCH_PPC",
+	"SCMP_ARCH_PPC64",
+	"SCMP_ARCH_PPC64LE",
+	"SCMP_ARCH_S390",
+	"SCMP_ARCH_S390X",
+	"SCMP_ARCH_PARISC",
+	"SCMP_ARCH_PARISC64",
+	"SCMP_ARCH_RISCV64",
+	"SCMP_ARCH_LOONGARCH64",
+	"SCMP_ARCH_M68K",
+	"SCMP_ARCH_SH",
+	"SCMP_ARCH_SHEB",
+)
+
+func validArchitecture(a string) error {
+	if !knownArchitectures.Has(a) {
+		return fmt.Errorf("unknown architecture: %s", a)
+	}
+	return nil
+}
+
+// knownFilterArches are the architecture names that may be used in
+// includes and excludes.
+var knownFilterArches = sets.NewString(
+	"386", "amd64", "arm", "arm64", "loong64", "mips", "mipsle", "mips64",
+	"mips64le", "mips64n32", "mipsle64n32", "ppc", "ppc64", "ppc64le",
+	"riscv64", "s390", "s390x", "x86", "x32",
+)
+
+// Validate checks that the filter only refers to known architectures and
+// capabilities, and that MinKernel is well formed.
+func (f *SeccompProfileFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, a := range f.Arches {
+		if !knownFilterArches.Has(a) {
+			return fmt.Errorf("unknown architecture: %s", a)
+		}
+	}
+	for _, c := range f.Caps {
+		if !strings.HasPrefix(c, "CAP_") {
+			return fmt.Errorf("invalid capability: %s", c)
+		}
+	}
+	if f.MinKernel != "" {
+		if _, err := ParseKernelVersion(f.MinKernel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KernelVersion is a kernel's major and minor version.
+//
+// +k8s:deepcopy-gen=false
+type KernelVersion struct {
+	Major, Minor int
+}
+
+// ParseKernelVersion parses a kernel version of the form "<major>.<minor>",
+// ignoring any further components or suffix such as in "5.15.0-1034-gke".
+func ParseKernelVersion(s string) (KernelVersion, error) {
+	var kv KernelVersion
+	parts := strings.SplitN(s, ".", 3)
+	if len(parts) < 2 {
+		return kv, fmt.Errorf("invalid kernel version: %q", s)
+	}
+	var err error
+	if kv.Major, err = strconv.Atoi(parts[0]); err != nil {
+		return kv, fmt.Errorf("invalid kernel version: %q", s)
+	}
+	minor := parts[1]
+	if i := strings.IndexFunc(minor, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minor = minor[:i]
+	}
+	if kv.Minor, err = strconv.Atoi(minor); err != nil {
+		return kv, fmt.Errorf("invalid kernel version: %q", s)
+	}
+	return kv, nil
+}
+
+// Less returns true if kv is an older kernel version than o.
+func (kv KernelVersion) Less(o KernelVersion) bool {
+	if kv.Major != o.Major {
+		return kv.Major < o.Major
+	}
+	return kv.Minor < o.Minor
+}
+
+// Valid returns an error if the builtin profile is not known.
+func (b BuiltinProfile) Valid() error {
+	switch b {
+	case BuiltinRuntimeDefault:
+		return nil
+	default:
+		return fmt.Errorf("unknown builtin profile: %s", b)
+	}
+}
+
+// maxArgIndex is the index of the last syscall argument seccomp can inspect.
+const maxArgIndex = 5
+
+// Validate implements apis.Validatable
+func (spec *SeccompProfileSpec) Validate(ctx context.Context) *apis.FieldError {
+	if spec.BaseProfileRef != nil {
+		ref := spec.BaseProfileRef
+		switch {
+		case ref.Name == "" && ref.Builtin == "":
+			return apis.ErrMissingOneOf("baseProfileRef.name", "baseProfileRef.builtin")
+		case ref.Name != "" && ref.Builtin != "":
+			return apis.ErrMultipleOneOf("baseProfileRef.name", "baseProfileRef.builtin")
+		case ref.Builtin != "":
+			if err := ref.Builtin.Valid(); err != nil {
+				return apis.ErrInvalidValue(ref.Builtin, "baseProfileRef.builtin", err.Error())
+			}
+		}
+		if spec.Contents == nil {
+			// Everything is inherited from the base profile.
+			return nil
+		}
+	} else {
+		if len(spec.RemoveSyscalls) != 0 {
+			return apis.ErrGeneric("removeSyscalls requires baseProfileRef", "removeSyscalls")
+		}
+		if spec.Contents == nil {
+			return apis.ErrMissingField("contents")
+		}
+	}
+
+	// A profile extending another may inherit its default action.
+	if spec.BaseProfileRef == nil || spec.Contents.DefaultAction != "" {
+		if err := spec.Contents.DefaultAction.Valid(); err != nil {
+			return apis.ErrInvalidValue(spec.Contents, "contents.defaultAction", fmt.Sprintf("invalid default action: %v", err))
+		}
+		if spec.Contents.DefaultAction == ActionNotify {
+			return apis.ErrInvalidValue(spec.Contents.DefaultAction, "contents.defaultAction", "SCMP_ACT_NOTIFY cannot be used as the default action")
+		}
+		if spec.Contents.DefaultErrnoRet != nil && !spec.Contents.DefaultAction.acceptsErrnoRet() {
+			return apis.ErrInvalidValue(*spec.Contents.DefaultErrnoRet, "contents.defaultErrnoRet", fmt.Sprintf("defaultErrnoRet cannot be used with default action %s", spec.Contents.DefaultAction))
+		}
+	}
+	for i, a := range spec.Contents.Architectures {
+		if err := validArchitecture(a); err != nil {
+			return apis.ErrInvalidValue(a, "contents.architectures", fmt.Sprintf("item %d: %v", i, err))
+		}
+	}
+	mains := sets.NewString()
+	for i, am := range spec.Contents.ArchMap {
+		if err := validArchitecture(am.Architecture); err != nil {
+			return apis.ErrInvalidValue(am.Architecture, "contents.archMap.architecture", fmt.Sprintf("item %d: %v", i, err))
+		}
+		if mains.Has(am.Architecture) {
+			return apis.ErrInvalidValue(am.Architecture, "contents.archMap.architecture", fmt.Sprintf("item %d: duplicate architecture", i))
+		}
+		mains.Insert(am.Architecture)
+		for _, sa := range am.SubArchitectures {
+			if err := validArchitecture(sa); err != nil {
+				return apis.ErrInvalidValue(sa, "contents.archMap.subArchitectures", fmt.Sprintf("item %d: %v", i, err))
+			}
+		}
+	}
+	// Architectures and ArchMap may both be set only if they describe the
+	// same set of architectures.
+	if len(spec.Contents.Architectures) != 0 && len(spec.Contents.ArchMap) != 0 &&
+		!sets.NewString(spec.Contents.Architectures...).Equal(sets.NewString(spec.Contents.AllArchitectures()...)) {
+		return apis.ErrGeneric("architectures and archMap specify different architectures", "contents.architectures", "contents.archMap")
+	}
+	for i, f := range spec.Contents.Flags {
+		if err := f.Valid(); err != nil {
+			return apis.ErrInvalidValue(f, "contents.flags", fmt.Sprintf("item %d: invalid flag: %v", i, err))
+		}
+	}
+	if lp := spec.Contents.ListenerPath; lp != "" && !filepath.IsAbs(lp) {
+		return apis.ErrInvalidValue(lp, "contents.listenerPath", "listenerPath must be an absolute path")
+	}
+	if spec.Contents.ListenerMetadata != "" && spec.Contents.ListenerPath == "" && spec.BaseProfileRef == nil {
+		return apis.ErrGeneric("listenerMetadata requires listenerPath", "contents.listenerMetadata")
+	}
+	for i, s := range spec.Contents.Syscalls {
+		if err := s.Action.Valid(); err != nil {
+			return apis.ErrInvalidValue(spec.Contents, "contents.syscalls.action", fmt.Sprintf("item %d: invalid action: %v", i, err))
+		}
+		if s.Action == ActionNotify && spec.Contents.ListenerPath == "" && spec.BaseProfileRef == nil {
+			return apis.ErrInvalidValue(s.Action, "contents.syscalls.action", fmt.Sprintf("item %d: SCMP_ACT_NOTIFY requires listenerPath", i))
+		}
+		if s.ErrnoRet != nil && !s.Action.acceptsErrnoRet() {
+			return apis.ErrInvalidValue(*s.ErrnoRet, "contents.syscalls.errnoRet", fmt.Sprintf("item %d: errnoRet cannot be used with action %s", i, s.Action))
+		}
+		if len(s.Names) == 0 {
+			return apis.ErrInvalidValue(spec.Contents, "contents.syscalls.names", fmt.Sprintf("item %d: must specify at least one syscall name", i))
+		}
+		if err := s.Includes.Validate(); err != nil {
+			return apis.ErrInvalidValue(s.Includes, "contents.syscalls.includes", fmt.Sprintf("item %d: %v", i, err))
+		}
+		if err := s.Excludes.Validate(); err != nil {
+			return apis.ErrInvalidValue(s.Excludes, "contents.syscalls.excludes", fmt.Sprintf("item %d: %v", i, err))
+		}
+		for j, a := range s.Args {
+			if a.Index > maxArgIndex {
+				return apis.ErrInvalidValue(a.Index, "contents.syscalls.args.index", fmt.Sprintf("item %d, arg %d: index must be between 0 and %d", i, j, maxArgIndex))
+			}
+			if err := a.Op.Valid(); err != nil {
+				return apis.ErrInvalidValue(a.Op, "contents.syscalls.args.op", fmt.Sprintf("item %d, arg %d: invalid operator: %v", i, j, err))
+			}
+		}
+	}
+
+	errs := spec.Contents.validateSyscallNames()
+	if errs.Filter(apis.ErrorLevel) != nil {
+		return errs
+	}
+	return errs.Also(spec.Contents.lint(spec.BaseProfileRef != nil))
+}
+
+// filterArchitectures maps the architecture names used in includes and
+// excludes to those used by libseccomp, for those with syscall tables.
+var filterArchitectures = map[string]string{
+	"amd64":   "SCMP_ARCH_X86_64",
+	"386":     "SCMP_ARCH_X86",
+	"x86":     "SCMP_ARCH_X86",
+	"x32":     "SCMP_ARCH_X32",
+	"arm64":   "SCMP_ARCH_AARCH64",
+	"arm":     "SCMP_ARCH_ARM",
+	"riscv64": "SCMP_ARCH_RISCV64",
+	"s390x":   "SCMP_ARCH_S390X",
+	"ppc64le": "SCMP_ARCH_PPC64LE",
+}
+
+// validateSyscallNames checks each rule's syscall names against the syscall
+// tables of the architectures the rule applies to. A name that isn't a
+// syscall on any of them is an error, most likely a typo. A name that is
+// only missing on some of the profile's architectures is a warning, since
+// runtimes ignore it there.
+func (c *SeccompProfileJSON) validateSyscallNames() *apis.FieldError {
+	// If no architectures are listed, the profile applies to whatever the
+	// node's architecture is.
+	arches := sets.NewString(c.AllArchitectures()...)
+	listed := arches.Len() != 0
+	if !listed {
+		arches.Insert(syscalls.Architectures()...)
+	}
+
+	missingOn := map[string]sets.String{}
+	for i, s := range c.Syscalls {
+		ruleArches := arches
+		if s.Includes != nil && len(s.Includes.Arches) != 0 {
+			ruleArches = sets.NewString()
+			for _, a := range s.Includes.Arches {
+				if arch, ok := filterArchitectures[a]; ok && arches.Has(arch) {
+					ruleArches.Insert(arch)
+				}
+			}
+		}
+		if s.Excludes != nil {
+			ruleArches = ruleArches.Clone()
+			for _, a := range s.Excludes.Arches {
+				ruleArches.Delete(filterArchitectures[a])
+			}
+		}
+
+		for _, n := range s.Names {
+			var known, missing []string
+			for _, arch := range ruleArches.List() {
+				table, ok := syscalls.Known(arch)
+				if !ok {
+					// Nothing is known about this architecture.
+					continue
+				}
+				if table.Has(n) {
+					known = append(known, arch)
+				} else {
+					missing = append(missing, arch)
+				}
+			}
+			if len(missing) != 0 && len(known) == 0 {
+				return apis.ErrInvalidValue(n, "contents.syscalls.names", fmt.Sprintf("item %d: unknown syscall %q", i, n))
+			}
+			for _, arch := range missing {
+				if missingOn[arch] == nil {
+					missingOn[arch] = sets.NewString()
+				}
+				missingOn[arch].Insert(n)
+			}
+		}
+	}
+	if !listed {
+		return nil
+	}
+
+	// Report one warning per architecture, since profiles written for many
+	// architectures name many syscalls that only exist on some.
+	var errs *apis.FieldError
+	for _, arch := range arches.List() {
+		if names, ok := missingOn[arch]; ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("syscalls that don't exist on %s: %s", arch, strings.Join(names.List(), ", ")),
+				"contents.syscalls.names").At(apis.WarningLevel))
+		}
+	}
+	return errs
+}
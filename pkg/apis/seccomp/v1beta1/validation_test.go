@@ -0,0 +1,348 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/utils/pointer"
+	"knative.dev/pkg/apis"
+)
+
+func TestSpecValidation(t *testing.T) {
+	for _, c := range []struct {
+		desc    string
+		spec    SeccompProfileSpec
+		wantErr bool
+	}{{
+		desc:    "missing contents",
+		spec:    SeccompProfileSpec{},
+		wantErr: true,
+	}, {
+		desc: "default only",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionLog,
+		}},
+	}, {
+		desc: "unknown default action",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: "SCMP_ACT_BOGUS",
+		}},
+		wantErr: true,
+	}, {
+		desc: "missing names",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Action: ActionAllow,
+			}},
+		}},
+		wantErr: true,
+	}, {
+		desc: "valid args",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"personality"},
+				Action: ActionAllow,
+				Args: []SeccompProfileArg{{
+					Index: 0,
+					Value: 0xffffffff,
+					Op:    OpEqualTo,
+				}},
+			}, {
+				Names:  []string{"clone"},
+				Action: ActionAllow,
+				Args: []SeccompProfileArg{{
+					Index:    5,
+					Value:    2114060288,
+					ValueTwo: 0,
+					Op:       OpMaskedEqual,
+				}},
+			}},
+		}},
+	}, {
+		desc: "arg index out of range",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"clone"},
+				Action: ActionAllow,
+				Args:   []SeccompProfileArg{{Index: 6, Op: OpEqualTo}},
+			}},
+		}},
+		wantErr: true,
+	}, {
+		desc: "unknown operator",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"clone"},
+				Action: ActionAllow,
+				Args:   []SeccompProfileArg{{Index: 0, Op: "SCMP_CMP_BOGUS"}},
+			}},
+		}},
+		wantErr: true,
+	}, {
+		desc: "kill actions with errno default",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction:   ActionErr,
+			DefaultErrnoRet: pointer.Uint(1),
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"ptrace"},
+				Action: ActionKillProcess,
+			}, {
+				Names:    []string{"mount"},
+				Action:   ActionErr,
+				ErrnoRet: pointer.Uint(38),
+			}},
+		}},
+	}, {
+		desc: "notify default action",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionNotify,
+		}},
+		wantErr: true,
+	}, {
+		desc: "defaultErrnoRet with allow default",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction:   ActionAllow,
+			DefaultErrnoRet: pointer.Uint(1),
+		}},
+		wantErr: true,
+	}, {
+		desc: "errnoRet with kill action",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:    []string{"ptrace"},
+				Action:   ActionKill,
+				ErrnoRet: pointer.Uint(1),
+			}},
+		}},
+		wantErr: true,
+	}, {
+		desc: "flags and listener",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction:    ActionAllow,
+			Flags:            []Flag{FlagLog, FlagWaitKillableRecv},
+			ListenerPath:     "/run/seccomp-agent.socket",
+			ListenerMetadata: "foo",
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"mount"},
+				Action: ActionNotify,
+			}},
+		}},
+	}, {
+		desc: "unknown flag",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			Flags:         []Flag{"SECCOMP_FILTER_FLAG_BOGUS"},
+		}},
+		wantErr: true,
+	}, {
+		desc: "notify without listener",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			Syscalls: []SeccompProfileSyscall{{
+				Names:  []string{"mount"},
+				Action: ActionNotify,
+			}},
+		}},
+		wantErr: true,
+	}, {
+		desc: "relative listener path",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionAllow,
+			ListenerPath:  "seccomp-agent.socket",
+		}},
+		wantErr: true,
+	}, {
+		desc: "listener metadata without path",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction:    ActionAllow,
+			ListenerMetadata: "foo",
+		}},
+		wantErr: true,
+	}, {
+		desc: "unknown architecture",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_Z80"},
+		}},
+		wantErr: true,
+	}, {
+		desc: "archMap",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture:     "SCMP_ARCH_X86_64",
+				SubArchitectures: []string{"SCMP_ARCH_X86", "SCMP_ARCH_X32"},
+			}, {
+				Architecture:     "SCMP_ARCH_AARCH64",
+				SubArchitectures: []string{"SCMP_ARCH_ARM"},
+			}},
+		}},
+	}, {
+		desc: "archMap with unknown sub-architecture",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture:     "SCMP_ARCH_X86_64",
+				SubArchitectures: []string{"SCMP_ARCH_Z80"},
+			}},
+		}},
+		wantErr: true,
+	}, {
+		desc: "archMap and matching architectures",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86", "SCMP_ARCH_X86_64"},
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture:     "SCMP_ARCH_X86_64",
+				SubArchitectures: []string{"SCMP_ARCH_X86"},
+			}},
+		}},
+	}, {
+		desc: "archMap and conflicting architectures",
+		spec: SeccompProfileSpec{Contents: &SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_AARCH64"},
+			ArchMap: []SeccompProfileArchMap{{
+				Architecture: "SCMP_ARCH_X86_64",
+			}},
+		}},
+		wantErr: true,
+	}, {
+		desc: "base profile only",
+		spec: SeccompProfileSpec{BaseProfileRef: &BaseProfileReference{Name: "baseline"}},
+	}, {
+		desc: "base profile with additions and removals",
+		spec: SeccompProfileSpec{
+			BaseProfileRef: &BaseProfileReference{Name: "baseline"},
+			RemoveSyscalls: []string{"ptrace"},
+			Contents: &SeccompProfileJSON{
+				Syscalls: []SeccompProfileSyscall{{
+					Names:  []string{"io_uring_setup"},
+					Action: ActionAllow,
+				}},
+			},
+		},
+	}, {
+		desc:    "base profile without name",
+		spec:    SeccompProfileSpec{BaseProfileRef: &BaseProfileReference{}},
+		wantErr: true,
+	}, {
+		desc: "removeSyscalls without base profile",
+		spec: SeccompProfileSpec{
+			RemoveSyscalls: []string{"ptrace"},
+			Contents:       &SeccompProfileJSON{DefaultAction: ActionErr},
+		},
+		wantErr: true,
+	}, {
+		desc: "builtin base profile",
+		spec: SeccompProfileSpec{
+			BaseProfileRef: &BaseProfileReference{Builtin: BuiltinRuntimeDefault},
+			RemoveSyscalls: []string{"io_uring_setup"},
+		},
+	}, {
+		desc:    "unknown builtin base profile",
+		spec:    SeccompProfileSpec{BaseProfileRef: &BaseProfileReference{Builtin: "Bogus"}},
+		wantErr: true,
+	}, {
+		desc: "both name and builtin base profile",
+		spec: SeccompProfileSpec{BaseProfileRef: &BaseProfileReference{
+			Name:    "baseline",
+			Builtin: BuiltinRuntimeDefault,
+		}},
+		wantErr: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			// Lint warnings are tested separately.
+			err := c.spec.Validate(context.Background()).Filter(apis.ErrorLevel)
+			if gotErr := err != nil; gotErr != c.wantErr {
+				t.Errorf("Validate() = %v, wantErr %t", err, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestSyscallNameValidation(t *testing.T) {
+	for _, c := range []struct {
+		desc              string
+		contents          SeccompProfileJSON
+		wantErr, wantWarn bool
+	}{{
+		desc: "known everywhere",
+		contents: SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"read", "openat"}, Action: ActionAllow}},
+		},
+	}, {
+		desc: "typo",
+		contents: SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"epoll_wiat"}, Action: ActionAllow}},
+		},
+		wantErr: true,
+	}, {
+		desc: "missing on a listed architecture",
+		contents: SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_AARCH64"},
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"open"}, Action: ActionAllow}},
+		},
+		wantWarn: true,
+	}, {
+		desc: "missing on an architecture the rule excludes",
+		contents: SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_AARCH64"},
+			Syscalls: []SeccompProfileSyscall{{
+				Names:    []string{"open"},
+				Action:   ActionAllow,
+				Includes: &SeccompProfileFilter{Arches: []string{"amd64"}},
+			}},
+		},
+	}, {
+		desc: "missing on the only listed architecture",
+		contents: SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_AARCH64"},
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"open"}, Action: ActionAllow}},
+		},
+		wantErr: true,
+	}, {
+		desc: "no table for the architecture",
+		contents: SeccompProfileJSON{
+			DefaultAction: ActionErr,
+			Architectures: []string{"SCMP_ARCH_MIPS"},
+			Syscalls:      []SeccompProfileSyscall{{Names: []string{"epoll_wiat"}, Action: ActionAllow}},
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			err := c.contents.validateSyscallNames()
+			if gotErr := err.Filter(apis.ErrorLevel) != nil; gotErr != c.wantErr {
+				t.Errorf("Validate() = %v, wantErr %t", err, c.wantErr)
+			}
+			if gotWarn := err.Filter(apis.WarningLevel) != nil; gotWarn != c.wantWarn {
+				t.Errorf("Validate() = %v, wantWarn %t", err, c.wantWarn)
+			}
+		})
+	}
+}
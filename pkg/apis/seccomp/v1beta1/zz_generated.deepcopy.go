@@ -0,0 +1,447 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BaseProfileReference) DeepCopyInto(out *BaseProfileReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaseProfileReference.
+func (in *BaseProfileReference) DeepCopy() *BaseProfileReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BaseProfileReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedSeccompProfile) DeepCopyInto(out *NamespacedSeccompProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedSeccompProfile.
+func (in *NamespacedSeccompProfile) DeepCopy() *NamespacedSeccompProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedSeccompProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedSeccompProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedSeccompProfileList) DeepCopyInto(out *NamespacedSeccompProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespacedSeccompProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedSeccompProfileList.
+func (in *NamespacedSeccompProfileList) DeepCopy() *NamespacedSeccompProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedSeccompProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespacedSeccompProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfile) DeepCopyInto(out *SeccompProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfile.
+func (in *SeccompProfile) DeepCopy() *SeccompProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SeccompProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileArchMap) DeepCopyInto(out *SeccompProfileArchMap) {
+	*out = *in
+	if in.SubArchitectures != nil {
+		in, out := &in.SubArchitectures, &out.SubArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileArchMap.
+func (in *SeccompProfileArchMap) DeepCopy() *SeccompProfileArchMap {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileArchMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileArg) DeepCopyInto(out *SeccompProfileArg) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileArg.
+func (in *SeccompProfileArg) DeepCopy() *SeccompProfileArg {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileArg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileDistribution) DeepCopyInto(out *SeccompProfileDistribution) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileDistribution.
+func (in *SeccompProfileDistribution) DeepCopy() *SeccompProfileDistribution {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileDistribution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileFilter) DeepCopyInto(out *SeccompProfileFilter) {
+	*out = *in
+	if in.Arches != nil {
+		in, out := &in.Arches, &out.Arches
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Caps != nil {
+		in, out := &in.Caps, &out.Caps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileFilter.
+func (in *SeccompProfileFilter) DeepCopy() *SeccompProfileFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileJSON) DeepCopyInto(out *SeccompProfileJSON) {
+	*out = *in
+	if in.DefaultErrnoRet != nil {
+		in, out := &in.DefaultErrnoRet, &out.DefaultErrnoRet
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ArchMap != nil {
+		in, out := &in.ArchMap, &out.ArchMap
+		*out = make([]SeccompProfileArchMap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Syscalls != nil {
+		in, out := &in.Syscalls, &out.Syscalls
+		*out = make([]SeccompProfileSyscall, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]Flag, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileJSON.
+func (in *SeccompProfileJSON) DeepCopy() *SeccompProfileJSON {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileJSON)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileList) DeepCopyInto(out *SeccompProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SeccompProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileList.
+func (in *SeccompProfileList) DeepCopy() *SeccompProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SeccompProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileNodeStatus) DeepCopyInto(out *SeccompProfileNodeStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.WriteTime.DeepCopyInto(&out.WriteTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileNodeStatus.
+func (in *SeccompProfileNodeStatus) DeepCopy() *SeccompProfileNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SeccompProfileNodeStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileNodeStatusList) DeepCopyInto(out *SeccompProfileNodeStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SeccompProfileNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileNodeStatusList.
+func (in *SeccompProfileNodeStatusList) DeepCopy() *SeccompProfileNodeStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileNodeStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SeccompProfileNodeStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileSpec) DeepCopyInto(out *SeccompProfileSpec) {
+	*out = *in
+	if in.BaseProfileRef != nil {
+		in, out := &in.BaseProfileRef, &out.BaseProfileRef
+		*out = new(BaseProfileReference)
+		**out = **in
+	}
+	if in.RemoveSyscalls != nil {
+		in, out := &in.RemoveSyscalls, &out.RemoveSyscalls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Contents != nil {
+		in, out := &in.Contents, &out.Contents
+		*out = new(SeccompProfileJSON)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileSpec.
+func (in *SeccompProfileSpec) DeepCopy() *SeccompProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileStatus) DeepCopyInto(out *SeccompProfileStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Distribution != nil {
+		in, out := &in.Distribution, &out.Distribution
+		*out = new(SeccompProfileDistribution)
+		**out = **in
+	}
+	if in.TranslatedSyscalls != nil {
+		in, out := &in.TranslatedSyscalls, &out.TranslatedSyscalls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileStatus.
+func (in *SeccompProfileStatus) DeepCopy() *SeccompProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeccompProfileSyscall) DeepCopyInto(out *SeccompProfileSyscall) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]SeccompProfileArg, len(*in))
+		copy(*out, *in)
+	}
+	if in.ErrnoRet != nil {
+		in, out := &in.ErrnoRet, &out.ErrnoRet
+		*out = new(uint)
+		**out = **in
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = new(SeccompProfileFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Excludes != nil {
+		in, out := &in.Excludes, &out.Excludes
+		*out = new(SeccompProfileFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeccompProfileSyscall.
+func (in *SeccompProfileSyscall) DeepCopy() *SeccompProfileSyscall {
+	if in == nil {
+		return nil
+	}
+	out := new(SeccompProfileSyscall)
+	in.DeepCopyInto(out)
+	return out
+}
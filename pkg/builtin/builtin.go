@@ -0,0 +1,51 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builtin holds the seccomp profiles shipped with the controller,
+// which SeccompProfiles can extend without copying them.
+package builtin
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+// runtimeDefault is Docker's default profile, from
+// github.com/docker/docker@v20.10.20/profiles/seccomp/default.json.
+// containerd's default profile is generated from the same rules.
+//
+//go:embed runtime-default.json
+var runtimeDefault []byte
+
+// Profile returns a copy of the named builtin profile.
+func Profile(name v1beta1.BuiltinProfile) (*v1beta1.SeccompProfileJSON, error) {
+	var b []byte
+	switch name {
+	case v1beta1.BuiltinRuntimeDefault:
+		b = runtimeDefault
+	default:
+		return nil, fmt.Errorf("unknown builtin profile: %s", name)
+	}
+
+	var out v1beta1.SeccompProfileJSON
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("parsing builtin profile %s: %w", name, err)
+	}
+	return &out, nil
+}
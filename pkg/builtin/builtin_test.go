@@ -0,0 +1,44 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builtin
+
+import (
+	"context"
+	"testing"
+
+	"knative.dev/pkg/apis"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+func TestRuntimeDefault(t *testing.T) {
+	p, err := Profile(v1beta1.BuiltinRuntimeDefault)
+	if err != nil {
+		t.Fatalf("Profile: %v", err)
+	}
+	if err := (&v1beta1.SeccompProfileSpec{Contents: p}).Validate(context.Background()).Filter(apis.ErrorLevel); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	// Callers may modify the profile they get.
+	p.Syscalls = nil
+	if p, err := Profile(v1beta1.BuiltinRuntimeDefault); err != nil {
+		t.Fatalf("Profile: %v", err)
+	} else if len(p.Syscalls) == 0 {
+		t.Error("Profile() returned no syscalls after a previous copy was modified")
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config holds the configuration shared by the controller and the
+// webhook, from the config-seccomp ConfigMap.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	cm "knative.dev/pkg/configmap"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap holding the configuration.
+	ConfigMapName = "config-seccomp"
+
+	directoryKey    = "directory"
+	fileNameKey     = "file-name-template"
+	fileModeKey     = "file-mode"
+	scopeKey        = "image-profile-scope"
+	sourcesKey      = "image-profile-sources"
+	imageFileKey    = "image-profile-file"
+	layerLimitKey   = "image-layer-size-limit"
+	defaultFileName = "{{.Name}}.json"
+)
+
+// ImageProfileScope is where the webhook applies profiles that images
+// declare.
+type ImageProfileScope string
+
+const (
+	// ScopeContainer sets each container's seccompProfile to the profile
+	// its image declares.
+	ScopeContainer ImageProfileScope = "container"
+
+	// ScopePod sets the pod's seccompProfile to the union of the profiles
+	// its containers' images declare.
+	ScopePod ImageProfileScope = "pod"
+)
+
+// ImageProfileSource is where the webhook looks for the profile an image
+// declares.
+type ImageProfileSource string
+
+const (
+	// SourceAnnotation is the seccomp.imjasonh.dev/profile annotation on
+	// the image's manifest.
+	SourceAnnotation ImageProfileSource = "annotation"
+
+	// SourceReferrers is an artifact that refers to the image.
+	SourceReferrers ImageProfileSource = "referrers"
+
+	// SourceLabel is the seccomp.imjasonh.dev/profile label in the
+	// image's config.
+	SourceLabel ImageProfileSource = "label"
+
+	// SourceFile is a file in the image's filesystem.
+	SourceFile ImageProfileSource = "file"
+)
+
+// DefaultImageLayerSizeLimit is the default limit of the size of the
+// layers read to find a profile in the image's filesystem.
+const DefaultImageLayerSizeLimit = 100 << 20
+
+// DefaultFileMode is the default mode of profile files, which the kubelet
+// and container runtime only need to read.
+const DefaultFileMode os.FileMode = 0644
+
+// Config is how profiles are laid out on nodes.
+type Config struct {
+	// Directory is the directory profiles are written to, relative to the
+	// kubelet's seccomp root, which pods' localhostProfiles begin with.
+	// Namespaced profiles are written to a subdirectory for their
+	// namespace.
+	Directory string
+
+	// FileName is the template of profiles' file names, given the
+	// profile's .Name.
+	FileName *template.Template
+
+	// FileMode is the mode of profile files.
+	FileMode os.FileMode
+
+	// ImageProfileScope is where the webhook applies profiles that images
+	// declare.
+	ImageProfileScope ImageProfileScope
+
+	// ImageProfileSources are where the webhook looks for the profile an
+	// image declares, in order. The first source with a profile is used.
+	ImageProfileSources []ImageProfileSource
+
+	// ImageProfileFile is the path of the profile in the image's
+	// filesystem, for SourceFile.
+	ImageProfileFile string
+
+	// ImageLayerSizeLimit is the most, in compressed bytes, of the layers
+	// of a pod's images that are read to find ImageProfileFile.
+	ImageLayerSizeLimit int64
+}
+
+// fileNameData is what file name templates are executed with.
+type fileNameData struct {
+	Name string
+}
+
+// File returns the path of a profile's file, relative to Directory.
+// Cluster-wide profiles are written to the root of Directory, and
+// namespaced profiles to a subdirectory for their namespace, so that they
+// can't overwrite cluster-wide profiles or those of other namespaces.
+func (c *Config) File(namespace, name string) string {
+	var b bytes.Buffer
+	// Templates are checked when the config is parsed.
+	_ = c.FileName.Execute(&b, fileNameData{Name: name})
+	return filepath.Join(namespace, b.String())
+}
+
+// LocalhostProfile returns the localhostProfile pods use to reference a
+// profile.
+func (c *Config) LocalhostProfile(namespace, name string) string {
+	return filepath.Join(c.Directory, c.File(namespace, name))
+}
+
+// defaultConfig returns the configuration when none is given.
+func defaultConfig() *Config {
+	return &Config{
+		Directory: "profiles",
+		FileName:  template.Must(template.New(fileNameKey).Parse(defaultFileName)),
+		FileMode:  DefaultFileMode,
+
+		ImageProfileScope:   ScopeContainer,
+		ImageProfileSources: []ImageProfileSource{SourceAnnotation, SourceReferrers, SourceLabel},
+		ImageProfileFile:    "/etc/seccomp/profile.json",
+		ImageLayerSizeLimit: DefaultImageLayerSizeLimit,
+	}
+}
+
+// NewConfigFromMap creates a Config from the supplied map.
+func NewConfigFromMap(data map[string]string) (*Config, error) {
+	c := defaultConfig()
+
+	fileName, fileMode, scope := defaultFileName, "0644", string(c.ImageProfileScope)
+	sources := make([]string, 0, len(c.ImageProfileSources))
+	for _, src := range c.ImageProfileSources {
+		sources = append(sources, string(src))
+	}
+	sourceList := strings.Join(sources, ",")
+	layerLimit := resource.NewQuantity(c.ImageLayerSizeLimit, resource.BinarySI)
+	if err := cm.Parse(data,
+		cm.AsString(directoryKey, &c.Directory),
+		cm.AsString(fileNameKey, &fileName),
+		cm.AsString(fileModeKey, &fileMode),
+		cm.AsString(scopeKey, &scope),
+		cm.AsString(sourcesKey, &sourceList),
+		cm.AsString(imageFileKey, &c.ImageProfileFile),
+		cm.AsQuantity(layerLimitKey, &layerLimit),
+	); err != nil {
+		return nil, fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	c.Directory = filepath.Clean(c.Directory)
+	if c.Directory == "." || filepath.IsAbs(c.Directory) || strings.HasPrefix(c.Directory, "..") {
+		return nil, fmt.Errorf("%s must be a subdirectory of the kubelet's seccomp root, got %q", directoryKey, data[directoryKey])
+	}
+
+	tmpl, err := template.New(fileNameKey).Option("missingkey=error").Parse(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", fileNameKey, err)
+	}
+	// File names must differ by profile name, stay in their directory,
+	// and not be hidden, since hidden files are used by the controller.
+	// They also mustn't be possible namespace names, so that cluster-wide
+	// profiles' files don't collide with namespaced profiles' directories.
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, fileNameData{Name: "example"}); err != nil {
+		return nil, fmt.Errorf("failed to execute %s: %w", fileNameKey, err)
+	}
+	if got := b.String(); !strings.Contains(got, "example") || strings.ContainsRune(got, '/') || strings.HasPrefix(got, ".") {
+		return nil, fmt.Errorf("%s must include {{.Name}} and give a file name that isn't hidden, got %q", fileNameKey, got)
+	} else if len(validation.IsDNS1123Label(got)) == 0 {
+		return nil, fmt.Errorf("%s must give file names that can't be namespace names, such as by ending in .json, got %q", fileNameKey, got)
+	}
+	c.FileName = tmpl
+
+	mode, err := strconv.ParseUint(fileMode, 8, 32)
+	if err != nil || mode == 0 || mode > 0777 {
+		return nil, fmt.Errorf("%s must be an octal file mode like 0644, got %q", fileModeKey, fileMode)
+	}
+	c.FileMode = os.FileMode(mode)
+
+	switch c.ImageProfileScope = ImageProfileScope(scope); c.ImageProfileScope {
+	case ScopeContainer, ScopePod:
+	default:
+		return nil, fmt.Errorf("%s must be %q or %q, got %q", scopeKey, ScopeContainer, ScopePod, scope)
+	}
+
+	c.ImageProfileSources = nil
+	seen := map[ImageProfileSource]bool{}
+	for _, s := range strings.Split(sourceList, ",") {
+		src := ImageProfileSource(strings.TrimSpace(s))
+		switch src {
+		case SourceAnnotation, SourceReferrers, SourceLabel, SourceFile:
+		default:
+			return nil, fmt.Errorf("%s must list %q, %q, %q or %q, got %q", sourcesKey, SourceAnnotation, SourceReferrers, SourceLabel, SourceFile, src)
+		}
+		if seen[src] {
+			return nil, fmt.Errorf("%s lists %q more than once", sourcesKey, src)
+		}
+		seen[src] = true
+		c.ImageProfileSources = append(c.ImageProfileSources, src)
+	}
+
+	if !filepath.IsAbs(c.ImageProfileFile) || filepath.Clean(c.ImageProfileFile) != c.ImageProfileFile || c.ImageProfileFile == "/" {
+		return nil, fmt.Errorf("%s must be the absolute path of a file, got %q", imageFileKey, c.ImageProfileFile)
+	}
+
+	if c.ImageLayerSizeLimit = layerLimit.Value(); c.ImageLayerSizeLimit <= 0 {
+		return nil, fmt.Errorf("%s must be positive, got %q", layerLimitKey, layerLimit.String())
+	}
+
+	return c, nil
+}
+
+// NewConfigFromConfigMap creates a Config from the supplied ConfigMap.
+func NewConfigFromConfigMap(configMap *corev1.ConfigMap) (*Config, error) {
+	return NewConfigFromMap(configMap.Data)
+}
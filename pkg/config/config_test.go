@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDefaults(t *testing.T) {
+	c, err := NewConfigFromMap(nil)
+	if err != nil {
+		t.Fatalf("NewConfigFromMap: %v", err)
+	}
+	if got, want := c.LocalhostProfile("", "audit"), "profiles/audit.json"; got != want {
+		t.Errorf("LocalhostProfile() = %q, want %q", got, want)
+	}
+	if got, want := c.LocalhostProfile("team-a", "audit"), "profiles/team-a/audit.json"; got != want {
+		t.Errorf("LocalhostProfile() = %q, want %q", got, want)
+	}
+	if c.FileMode != DefaultFileMode {
+		t.Errorf("FileMode = %v, want %v", c.FileMode, DefaultFileMode)
+	}
+	if c.ImageProfileScope != ScopeContainer {
+		t.Errorf("ImageProfileScope = %q, want %q", c.ImageProfileScope, ScopeContainer)
+	}
+	if d := cmp.Diff([]ImageProfileSource{SourceAnnotation, SourceReferrers, SourceLabel}, c.ImageProfileSources); d != "" {
+		t.Errorf("ImageProfileSources (-want,+got): %s", d)
+	}
+	if c.ImageLayerSizeLimit != DefaultImageLayerSizeLimit {
+		t.Errorf("ImageLayerSizeLimit = %d, want %d", c.ImageLayerSizeLimit, DefaultImageLayerSizeLimit)
+	}
+}
+
+func TestNewConfigFromMap(t *testing.T) {
+	c, err := NewConfigFromMap(map[string]string{
+		"directory":              "operator/seccomp/",
+		"file-name-template":     "sp-{{.Name}}.json",
+		"file-mode":              "0640",
+		"image-profile-scope":    "pod",
+		"image-profile-sources":  "file, annotation",
+		"image-profile-file":     "/seccomp.json",
+		"image-layer-size-limit": "10Mi",
+	})
+	if err != nil {
+		t.Fatalf("NewConfigFromMap: %v", err)
+	}
+	if got, want := c.File("team-a", "audit"), "team-a/sp-audit.json"; got != want {
+		t.Errorf("File() = %q, want %q", got, want)
+	}
+	if got, want := c.LocalhostProfile("", "audit"), "operator/seccomp/sp-audit.json"; got != want {
+		t.Errorf("LocalhostProfile() = %q, want %q", got, want)
+	}
+	if got, want := c.FileMode, os.FileMode(0640); got != want {
+		t.Errorf("FileMode = %v, want %v", got, want)
+	}
+	if got, want := c.ImageProfileScope, ScopePod; got != want {
+		t.Errorf("ImageProfileScope = %q, want %q", got, want)
+	}
+	if d := cmp.Diff([]ImageProfileSource{SourceFile, SourceAnnotation}, c.ImageProfileSources); d != "" {
+		t.Errorf("ImageProfileSources (-want,+got): %s", d)
+	}
+	if got, want := c.ImageProfileFile, "/seccomp.json"; got != want {
+		t.Errorf("ImageProfileFile = %q, want %q", got, want)
+	}
+	if got, want := c.ImageLayerSizeLimit, int64(10<<20); got != want {
+		t.Errorf("ImageLayerSizeLimit = %d, want %d", got, want)
+	}
+}
+
+func TestNewConfigFromMapErrors(t *testing.T) {
+	for name, data := range map[string]map[string]string{
+		"absolute directory":     {"directory": "/etc"},
+		"escaping directory":     {"directory": "../etc"},
+		"empty directory":        {"directory": "."},
+		"unparseable template":   {"file-name-template": "{{.Name"},
+		"unknown field":          {"file-name-template": "{{.Namespace}}.json"},
+		"template without name":  {"file-name-template": "profile.json"},
+		"template with slash":    {"file-name-template": "{{.Name}}/profile.json"},
+		"hidden file":            {"file-name-template": ".{{.Name}}.json"},
+		"namespace name":         {"file-name-template": "{{.Name}}"},
+		"namespace name prefix":  {"file-name-template": "sp-{{.Name}}"},
+		"non-octal mode":         {"file-mode": "rw-r--r--"},
+		"mode with extra bits":   {"file-mode": "04755"},
+		"mode without any perms": {"file-mode": "0"},
+		"unknown scope":          {"image-profile-scope": "node"},
+		"unknown source":         {"image-profile-sources": "annotation,env"},
+		"repeated source":        {"image-profile-sources": "label,annotation,label"},
+		"no sources":             {"image-profile-sources": ""},
+		"relative image file":    {"image-profile-file": "etc/seccomp.json"},
+		"image root":             {"image-profile-file": "/"},
+		"zero layer size limit":  {"image-layer-size-limit": "0"},
+		"bad layer size limit":   {"image-layer-size-limit": "lots"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewConfigFromMap(data); err == nil {
+				t.Errorf("NewConfigFromMap(%v) = nil error", data)
+			}
+		})
+	}
+}
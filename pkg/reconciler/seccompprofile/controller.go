@@ -22,43 +22,201 @@ import (
 	"os"
 	"os/user"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	nodeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/node"
+	agentinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 
-	seccompprofileinformer "github.com/imjasonh/seccomp-profile/pkg/apis/injection/informers/seccomp/v1alpha1/seccompprofile"
-	seccompprofilereconciler "github.com/imjasonh/seccomp-profile/pkg/apis/injection/reconciler/seccomp/v1alpha1/seccompprofile"
+	seccompclient "github.com/imjasonh/seccomp-profile/pkg/apis/injection/client"
+
+	namespacedseccompprofileinformer "github.com/imjasonh/seccomp-profile/pkg/apis/injection/informers/seccomp/v1beta1/namespacedseccompprofile"
+	seccompprofileinformer "github.com/imjasonh/seccomp-profile/pkg/apis/injection/informers/seccomp/v1beta1/seccompprofile"
+	nodestatusinformer "github.com/imjasonh/seccomp-profile/pkg/apis/injection/informers/seccomp/v1beta1/seccompprofilenodestatus"
+	namespacedseccompprofilereconciler "github.com/imjasonh/seccomp-profile/pkg/apis/injection/reconciler/seccomp/v1beta1/namespacedseccompprofile"
+	seccompprofilereconciler "github.com/imjasonh/seccomp-profile/pkg/apis/injection/reconciler/seccomp/v1beta1/seccompprofile"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
 )
 
-// NewController creates a Reconciler and returns the result of NewImpl.
-func NewController(
-	ctx context.Context,
-	cmw configmap.Watcher,
-) *controller.Impl {
-	logger := logging.FromContext(ctx)
+// NewController returns a constructor that creates a Reconciler writing
+// profiles with w, and returns the result of NewImpl.
+func NewController(w *Writer) injection.ControllerConstructor {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+
+		// Do a quick check that we can list the local directory.
+		if u, err := user.Current(); err != nil {
+			logger.Fatalf("Failed to get current user: %v", err)
+		} else {
+			logger.Infof("Running as user %s (uid=%s gid=%s)", u.Username, u.Uid, u.Gid)
+		}
+		if err := listFiles(ctx); err != nil {
+			logger.Fatalf("Failed to list files: %v", err)
+		}
+
+		informer := seccompprofileinformer.Get(ctx)
+
+		r := &Reconciler{profileReconciler{
+			writer:   w,
+			statuses: newNodeStatuses(ctx),
+			lister:   informer.Lister(),
+		}}
+		var store *config.Store
+		impl := seccompprofilereconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+			store = newConfigStore(ctx, cmw, impl, informer.Informer())
+			return controller.Options{ConfigStore: store}
+		})
+		r.tracker = impl.Tracker
+		informer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+		// Restore files that are modified or removed, and stop checking those
+		// of deleted profiles.
+		r.writer.watchDrift(ctx, false, func(_, name string) {
+			impl.EnqueueKey(types.NamespacedName{Name: name})
+		})
+		informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: forget(r.writer)})
+
+		// Remove the files of deleted profiles, of either kind, at startup,
+		// periodically, and soon after they're deleted.
+		nsInformer := namespacedseccompprofileinformer.Get(ctx)
+		sweeper := newSweeper(kubeclient.Get(ctx), informer.Lister(), nsInformer.Lister(), store.Load, nodeName(ctx))
+		informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: sweeper.Trigger})
+		nsInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: sweeper.Trigger})
+		go sweeper.Run(ctx, func() bool {
+			return informer.Informer().HasSynced() && nsInformer.Informer().HasSynced()
+		})
 
-	// Do a quick check that we can list the local directory.
-	if u, err := user.Current(); err != nil {
-		logger.Fatalf("Failed to get current user: %v", err)
-	} else {
-		logger.Infof("Running as user %s (uid=%s gid=%s)", u.Username, u.Uid, u.Gid)
+		// Summarize the distribution of profiles when nodes write them. The
+		// statuses are namespaced, but their owners aren't, so enqueue the
+		// owner by name alone.
+		watchDistribution(ctx, impl, informer.Informer(), v1beta1.SchemeGroupVersion.WithKind("SeccompProfile"), func(obj interface{}) {
+			if object, err := kmeta.DeletionHandlingAccessor(obj); err == nil {
+				if owner := metav1.GetControllerOf(object); owner != nil {
+					impl.EnqueueKey(types.NamespacedName{Name: owner.Name})
+				}
+			}
+		})
+
+		// Reconcile profiles when the profiles they extend change.
+		informer.Informer().AddEventHandler(controller.HandleAll(
+			controller.EnsureTypeMeta(r.tracker.OnChanged, v1beta1.SchemeGroupVersion.WithKind("SeccompProfile")),
+		))
+		return impl
 	}
-	if err := listFiles(ctx); err != nil {
-		logger.Fatalf("Failed to list files: %v", err)
+}
+
+// NewNamespacedController returns a constructor that creates a
+// NamespacedReconciler writing profiles with w, and returns the result of
+// NewImpl.
+func NewNamespacedController(w *Writer) injection.ControllerConstructor {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		informer := namespacedseccompprofileinformer.Get(ctx)
+		baseInformer := seccompprofileinformer.Get(ctx)
+
+		r := &NamespacedReconciler{profileReconciler{
+			writer:   w,
+			statuses: newNodeStatuses(ctx),
+			lister:   baseInformer.Lister(),
+		}}
+		impl := namespacedseccompprofilereconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+			return controller.Options{ConfigStore: newConfigStore(ctx, cmw, impl, informer.Informer())}
+		})
+		r.tracker = impl.Tracker
+		informer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+		// Summarize the distribution of profiles when nodes write them.
+		watchDistribution(ctx, impl, informer.Informer(), v1beta1.SchemeGroupVersion.WithKind("NamespacedSeccompProfile"), impl.EnqueueControllerOf)
+
+		// Restore files that are modified or removed, and stop checking those
+		// of deleted profiles.
+		r.writer.watchDrift(ctx, true, func(namespace, name string) {
+			impl.EnqueueKey(types.NamespacedName{Namespace: namespace, Name: name})
+		})
+		informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{DeleteFunc: forget(r.writer)})
+
+		// Reconcile profiles when the profiles they extend change.
+		baseInformer.Informer().AddEventHandler(controller.HandleAll(
+			controller.EnsureTypeMeta(r.tracker.OnChanged, v1beta1.SchemeGroupVersion.WithKind("SeccompProfile")),
+		))
+		return impl
+	}
+}
+
+func newNodeStatuses(ctx context.Context) *nodeStatuses {
+	return &nodeStatuses{
+		client:   seccompclient.Get(ctx),
+		lister:   nodestatusinformer.Get(ctx).Lister(),
+		nodes:    nodeinformer.Get(ctx).Lister(),
+		agents:   agentinformer.Get(ctx, AgentSelector).Lister(),
+		nodeName: nodeName(ctx),
+	}
+}
+
+// nodeName returns the name of the node the controller is running on.
+func nodeName(ctx context.Context) string {
+	name := os.Getenv("NODE_NAME")
+	if name == "" {
+		logging.FromContext(ctx).Fatal("NODE_NAME must be set")
 	}
+	return name
+}
+
+// watchDistribution enqueues profiles of the kind when their node statuses
+// change, and every profile when nodes are added or removed, or the
+// controller is scheduled to or removed from a node.
+func watchDistribution(ctx context.Context, impl *controller.Impl, profiles cache.SharedInformer, gvk schema.GroupVersionKind, enqueueOwner func(interface{})) {
+	nodestatusinformer.Get(ctx).Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGVK(gvk),
+		Handler:    controller.HandleAll(enqueueOwner),
+	})
+	nodeinformer.Get(ctx).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { impl.GlobalResync(profiles) },
+		DeleteFunc: func(interface{}) { impl.GlobalResync(profiles) },
+	})
+	agentinformer.Get(ctx, AgentSelector).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { impl.GlobalResync(profiles) },
+		UpdateFunc: func(old, new interface{}) {
+			if o, n := old.(*corev1.Pod), new.(*corev1.Pod); o.Spec.NodeName != n.Spec.NodeName {
+				impl.GlobalResync(profiles)
+			}
+		},
+		DeleteFunc: func(interface{}) { impl.GlobalResync(profiles) },
+	})
+}
 
-	informer := seccompprofileinformer.Get(ctx)
+// forget returns a handler for deleted profiles that stops the writer
+// checking their files for drift.
+func forget(w *Writer) func(interface{}) {
+	return func(obj interface{}) {
+		if object, err := kmeta.DeletionHandlingAccessor(obj); err == nil {
+			w.forget(object.GetNamespace(), object.GetName())
+		}
+	}
+}
 
-	r := &Reconciler{}
-	impl := seccompprofilereconciler.NewImpl(ctx, r)
-	informer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
-	return impl
+// newConfigStore watches the configuration, and reconciles every profile
+// when it changes, to write them where it says.
+func newConfigStore(ctx context.Context, cmw configmap.Watcher, impl *controller.Impl, profiles cache.SharedInformer) *config.Store {
+	store := config.NewStore(logging.FromContext(ctx).Named("config-store"), func(string, interface{}) {
+		impl.GlobalResync(profiles)
+	})
+	store.WatchConfigs(cmw)
+	return store
 }
 
 func listFiles(ctx context.Context) error {
 	logger := logging.FromContext(ctx)
 
-	fis, err := os.ReadDir(path)
+	fis, err := os.ReadDir(root)
 	if err != nil {
 		return fmt.Errorf("failed to list files: %w", err)
 	}
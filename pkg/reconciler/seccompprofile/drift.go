@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+)
+
+// driftInterval is how often the files written are checked for drift, in
+// addition to when the profiles directory changes.
+const driftInterval = time.Minute
+
+var driftM = stats.Int64(
+	"profile_drift_count",
+	"Number of profile files restored after being modified or removed on the node",
+	stats.UnitDimensionless)
+
+func init() {
+	if err := metrics.RegisterResourceView(&view.View{
+		Description: driftM.Description(),
+		Measure:     driftM,
+		Aggregation: view.Count(),
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// reportDrift reports that the profile's file was restored on the node, in
+// an event on obj and a metric.
+func reportDrift(ctx context.Context, obj runtime.Object, fn, nodeName string) {
+	logging.FromContext(ctx).Warnf("restored %s, which was modified or removed", fn)
+	metrics.Record(ctx, driftM.M(1))
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Eventf(obj, corev1.EventTypeWarning, "DriftCorrected",
+			"Restored %s on node %s, which was modified or removed", fn, nodeName)
+	}
+}
+
+// watchDrift sets how the controller of cluster-wide, or namespaced,
+// profiles enqueues those whose files have drifted, and starts watching for
+// drift if it hasn't started yet. Reconciling them restores and reports the
+// files.
+func (w *Writer) watchDrift(ctx context.Context, namespaced bool, enqueue func(namespace, name string)) {
+	w.mu.Lock()
+	if namespaced {
+		w.enqueueNamespaced = enqueue
+	} else {
+		w.enqueueCluster = enqueue
+	}
+	w.mu.Unlock()
+	w.watchOnce.Do(func() { go w.runDrift(ctx) })
+}
+
+// runDrift enqueues the profiles whose files have drifted, checking
+// whenever the profiles directory changes and every driftInterval, until
+// the context is done.
+func (w *Writer) runDrift(ctx context.Context) {
+	logger := logging.FromContext(ctx)
+
+	changed := make(chan struct{}, 1)
+	if err := watchDir(ctx, root, func() {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}); err != nil {
+		// The periodic check still detects drift, just later.
+		logger.Errorw("Failed to watch profiles directory", "error", err)
+	}
+
+	ticker := time.NewTicker(driftInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-changed:
+		}
+		for _, f := range w.drifted() {
+			logger.Infof("profile file for %s/%s drifted", f.namespace, f.name)
+			if enqueue := w.enqueueFor(f.namespace); enqueue != nil {
+				enqueue(f.namespace, f.name)
+			}
+		}
+	}
+}
+
+// enqueueFor returns how profiles in the namespace, or cluster-wide ones if
+// it's empty, are enqueued, or nil if their controller hasn't started.
+func (w *Writer) enqueueFor(namespace string) func(namespace, name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if namespace == "" {
+		return w.enqueueCluster
+	}
+	return w.enqueueNamespaced
+}
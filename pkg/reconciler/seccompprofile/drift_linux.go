@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+	"knative.dev/pkg/logging"
+)
+
+const watchMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_DELETE_SELF |
+	unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_CLOSE_WRITE | unix.IN_ATTRIB
+
+// maxWatchDepth is how deep subdirectories are watched, which for the
+// kubelet's seccomp root includes the profiles directory and its
+// subdirectories for namespaced profiles.
+const maxWatchDepth = 2
+
+// watchDir calls changed whenever anything in dir, or its subdirectories
+// up to maxWatchDepth, changes, until the context is done.
+func watchDir(ctx context.Context, dir string, changed func()) error {
+	fd, err := unix.InotifyInit1(unix.IN_NONBLOCK | unix.IN_CLOEXEC)
+	if err != nil {
+		return fmt.Errorf("inotify_init: %w", err)
+	}
+	// A non-blocking file uses the runtime poller, so closing it ends reads.
+	f := os.NewFile(uintptr(fd), "inotify")
+
+	addWatches := func() error {
+		return filepath.WalkDir(dir, func(fn string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			// Adding a watch again just updates it.
+			if _, err := unix.InotifyAddWatch(fd, fn, watchMask); err != nil {
+				return fmt.Errorf("inotify_add_watch %s: %w", fn, err)
+			}
+			if rel, _ := filepath.Rel(dir, fn); strings.Count(rel, string(filepath.Separator)) >= maxWatchDepth-1 {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+	}
+	if err := addWatches(); err != nil {
+		f.Close()
+		return err
+	}
+
+	go func() {
+		<-ctx.Done()
+		f.Close()
+	}()
+	go func() {
+		logger := logging.FromContext(ctx)
+		buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+		for {
+			// Events aren't parsed, since any change is checked the same way.
+			if _, err := f.Read(buf); err != nil {
+				if ctx.Err() == nil {
+					logger.Errorw("Failed to read inotify events", "error", err)
+				}
+				return
+			}
+			// Watch new directories.
+			if err := addWatches(); err != nil {
+				logger.Errorw("Failed to watch profiles directory", "error", err)
+			}
+			changed()
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchDir(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dir := t.TempDir()
+
+	changed := make(chan struct{}, 10)
+	if err := watchDir(ctx, dir, func() { changed <- struct{}{} }); err != nil {
+		t.Fatalf("watchDir: %v", err)
+	}
+
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-changed:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no change reported after %s", what)
+		}
+		// Drain events for the same change.
+		time.Sleep(50 * time.Millisecond)
+		for len(changed) > 0 {
+			<-changed
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "audit.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wait("writing a file")
+
+	// Namespace directories created later are watched too.
+	if err := os.Mkdir(filepath.Join(dir, "team-a"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wait("creating a directory")
+	if err := os.WriteFile(filepath.Join(dir, "team-a", "audit.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wait("writing a file in a subdirectory")
+}
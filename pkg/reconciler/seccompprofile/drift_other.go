@@ -0,0 +1,30 @@
+//go:build !linux
+
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"errors"
+)
+
+// watchDir isn't supported off Linux, where drift is only detected
+// periodically.
+func watchDir(context.Context, string, func()) error {
+	return errors.New("watching directories is only supported on Linux")
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+// indexFile is the name of the index in the profiles directory. It's
+// hidden, so that it isn't mistaken for a profile.
+const indexFile = ".seccomp-profile-index.json"
+
+// indexMode is the mode of the index, which tools on the node can read.
+const indexMode os.FileMode = 0644
+
+// indexes holds the index of each directory profiles have been written to,
+// shared by the writers of both kinds of profile and the sweeper.
+var indexes = struct {
+	sync.Mutex
+	m map[string]*index
+}{m: map[string]*index{}}
+
+// indexIn returns the index of the profiles directory.
+func indexIn(dir string) *index {
+	indexes.Lock()
+	defer indexes.Unlock()
+	i, ok := indexes.m[dir]
+	if !ok {
+		i = newIndex(filepath.Join(dir, indexFile))
+		indexes.m[dir] = i
+	}
+	return i
+}
+
+// indexEntry describes a profile file written to the node.
+type indexEntry struct {
+	// File is the path of the file, relative to the profiles directory.
+	File string `json:"file"`
+
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name"`
+	UID        types.UID `json:"uid"`
+	Generation int64     `json:"generation"`
+
+	// ContentHash is the hash of the profile's contents, as in its status.
+	ContentHash string `json:"contentHash"`
+
+	// WriteTime is when the file was last written.
+	WriteTime metav1.Time `json:"writeTime"`
+}
+
+// indexJSON is the format of the index file, which lists the entries
+// sorted by file so that tools on the node can read it.
+type indexJSON struct {
+	Profiles []indexEntry `json:"profiles"`
+}
+
+// index records which files in the profiles directory this controller
+// wrote, and for which profiles. Only files in the index are ever removed.
+type index struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]indexEntry // By File.
+}
+
+func newIndex(path string) *index {
+	return &index{path: path}
+}
+
+// loadLocked reads the index file the first time it's needed. A missing
+// or unreadable index is treated as empty, so that no files are removed.
+func (i *index) loadLocked(ctx context.Context) {
+	if i.entries != nil {
+		return
+	}
+	i.entries = map[string]indexEntry{}
+
+	b, err := os.ReadFile(i.path)
+	if os.IsNotExist(err) {
+		return
+	} else if err != nil {
+		logging.FromContext(ctx).Errorw("Failed to read index", "error", err)
+		return
+	}
+	var j indexJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		logging.FromContext(ctx).Errorw("Failed to parse index", "error", err)
+		return
+	}
+	for _, e := range j.Profiles {
+		i.entries[e.File] = e
+	}
+}
+
+func (i *index) saveLocked(ctx context.Context) error {
+	j := indexJSON{Profiles: make([]indexEntry, 0, len(i.entries))}
+	for _, e := range i.entries {
+		j.Profiles = append(j.Profiles, e)
+	}
+	sort.Slice(j.Profiles, func(a, b int) bool { return j.Profiles[a].File < j.Profiles[b].File })
+
+	b, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := writeFile(ctx, i.path, append(b, '\n'), indexMode); err != nil {
+		return fmt.Errorf("error writing index: %w", err)
+	}
+	return nil
+}
+
+// record adds or updates the entry for a file. If the file wasn't written,
+// the previous write time is kept.
+func (i *index) record(ctx context.Context, e indexEntry, written bool) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.loadLocked(ctx)
+
+	old, ok := i.entries[e.File]
+	if ok && !written && !old.WriteTime.IsZero() {
+		e.WriteTime = old.WriteTime
+	}
+	if ok && old == e {
+		return nil
+	}
+	i.entries[e.File] = e
+	return i.saveLocked(ctx)
+}
+
+// owns returns true if the file, relative to the profiles directory, was
+// written by this controller.
+func (i *index) owns(ctx context.Context, file string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.loadLocked(ctx)
+	_, ok := i.entries[file]
+	return ok
+}
+
+// files returns the files in the index, relative to the profiles
+// directory.
+func (i *index) files(ctx context.Context) []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.loadLocked(ctx)
+	out := make([]string, 0, len(i.entries))
+	for f := range i.entries {
+		out = append(out, f)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// remove removes the entry for a file, once it's removed.
+func (i *index) remove(ctx context.Context, file string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.loadLocked(ctx)
+	if _, ok := i.entries[file]; !ok {
+		return nil
+	}
+	delete(i.entries, file)
+	return i.saveLocked(ctx)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIndex(t *testing.T) {
+	ctx := context.Background()
+	fn := filepath.Join(t.TempDir(), indexFile)
+	first := metav1.NewTime(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(first.Add(time.Hour))
+
+	idx := newIndex(fn)
+	e := indexEntry{
+		File:        "audit.json",
+		Kind:        "SeccompProfile",
+		Name:        "audit",
+		UID:         "abc",
+		Generation:  1,
+		ContentHash: "sha256:1",
+		WriteTime:   first,
+	}
+	if err := idx.record(ctx, e, true); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	// Recording a new generation without writing the file keeps the write
+	// time.
+	e.Generation, e.WriteTime = 2, later
+	if err := idx.record(ctx, e, false); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	// The index is read back from the file.
+	reloaded := newIndex(fn)
+	if !reloaded.owns(ctx, "audit.json") {
+		t.Error("owns(audit.json) = false after reloading")
+	}
+	if reloaded.owns(ctx, "other.json") {
+		t.Error("owns(other.json) = true")
+	}
+	want := e
+	want.WriteTime = first
+	if diff := cmp.Diff(want, reloaded.entries["audit.json"]); diff != "" {
+		t.Errorf("entry (-want, +got) = %s", diff)
+	}
+
+	if err := reloaded.remove(ctx, "audit.json"); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if got := newIndex(fn).files(ctx); len(got) != 0 {
+		t.Errorf("files() = %v after removing", got)
+	}
+}
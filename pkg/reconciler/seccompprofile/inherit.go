@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/tracker"
+
+	v1beta1listers "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/builtin"
+)
+
+// flatten returns the contents of the profile merged over those of its base
+// profiles, if it has any, ending at a builtin profile if one is named.
+// self is the name of the profile if it is a cluster-wide SeccompProfile,
+// so it's detected if the profile is its own ancestor. track is called
+// with the name of each base profile, so that the caller can be notified
+// when they change.
+func flatten(ctx context.Context, lister v1beta1listers.SeccompProfileLister, self string, spec *v1beta1.SeccompProfileSpec, track func(name string) error) (*v1beta1.SeccompProfileJSON, error) {
+	chain := []string{self}
+	seen := sets.NewString(self)
+
+	// Collect the specs from this one up to the root.
+	specs := []*v1beta1.SeccompProfileSpec{spec}
+	for ref := spec.BaseProfileRef; ref != nil; {
+		if ref.Builtin != "" {
+			contents, err := builtin.Profile(ref.Builtin)
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, &v1beta1.SeccompProfileSpec{Contents: contents})
+			break
+		}
+
+		chain = append(chain, ref.Name)
+		if seen.Has(ref.Name) {
+			return nil, fmt.Errorf("base profile cycle: %s", strings.Join(chain, " -> "))
+		}
+		seen.Insert(ref.Name)
+
+		if err := track(ref.Name); err != nil {
+			return nil, err
+		}
+		base, err := lister.Get(ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("getting base profile %q: %w", ref.Name, err)
+		}
+		specs = append(specs, &base.Spec)
+		ref = base.Spec.BaseProfileRef
+	}
+
+	// Merge them from the root down.
+	out := specs[len(specs)-1].Contents.DeepCopy()
+	for i := len(specs) - 2; i >= 0; i-- {
+		out = merge(out, specs[i])
+	}
+
+	// Check that the merged profile is complete and consistent.
+	merged := &v1beta1.SeccompProfileSpec{Contents: out}
+	merged.SetDefaults(ctx)
+	if err := merged.Validate(ctx).Filter(apis.ErrorLevel); err != nil {
+		return nil, fmt.Errorf("invalid merged profile: %w", err)
+	}
+
+	// Nothing overrides the merged profile, so rules repeating its default
+	// action can go, and profiles that behave the same get the same hash.
+	out.Canonicalize()
+	return out, nil
+}
+
+// baseProfileReference returns a tracker reference to a SeccompProfile.
+func baseProfileReference(name string) tracker.Reference {
+	return tracker.Reference{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       "SeccompProfile",
+		Name:       name,
+	}
+}
+
+// merge returns the base profile extended by spec. Syscalls named by spec,
+// either in its rules or in RemoveSyscalls, are removed from the base
+// profile's rules, then spec's rules are added. Other fields in spec's
+// contents override the base profile's if they are set.
+func merge(base *v1beta1.SeccompProfileJSON, spec *v1beta1.SeccompProfileSpec) *v1beta1.SeccompProfileJSON {
+	if base == nil {
+		base = &v1beta1.SeccompProfileJSON{}
+	}
+	out := base.DeepCopy()
+	local := spec.Contents
+	if local == nil {
+		local = &v1beta1.SeccompProfileJSON{}
+	}
+
+	remove := sets.NewString(spec.RemoveSyscalls...)
+	for _, s := range local.Syscalls {
+		remove.Insert(s.Names...)
+	}
+	out.Syscalls = nil
+	for _, s := range base.DeepCopy().Syscalls {
+		var names []string
+		for _, n := range s.Names {
+			if !remove.Has(n) {
+				names = append(names, n)
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+		s.Names = names
+		out.Syscalls = append(out.Syscalls, s)
+	}
+	out.Syscalls = append(out.Syscalls, local.DeepCopy().Syscalls...)
+
+	if local.DefaultAction != "" {
+		out.DefaultAction = local.DefaultAction
+		out.DefaultErrnoRet = nil
+	}
+	if local.DefaultErrnoRet != nil {
+		out.DefaultErrnoRet = local.DefaultErrnoRet
+	}
+	if len(local.Architectures) != 0 || len(local.ArchMap) != 0 {
+		out.Architectures = local.Architectures
+		out.ArchMap = local.ArchMap
+	}
+	if len(local.Flags) != 0 {
+		out.Flags = local.Flags
+	}
+	if local.ListenerPath != "" {
+		out.ListenerPath = local.ListenerPath
+		out.ListenerMetadata = local.ListenerMetadata
+	}
+	if local.ListenerMetadata != "" {
+		out.ListenerMetadata = local.ListenerMetadata
+	}
+	return out
+}
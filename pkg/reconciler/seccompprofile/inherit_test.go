@@ -0,0 +1,178 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1beta1listers "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+func listerFor(t *testing.T, profiles ...*v1beta1.SeccompProfile) v1beta1listers.SeccompProfileLister {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, p := range profiles {
+		if err := indexer.Add(p); err != nil {
+			t.Fatalf("Add(%s): %v", p.Name, err)
+		}
+	}
+	return v1beta1listers.NewSeccompProfileLister(indexer)
+}
+
+func TestFlatten(t *testing.T) {
+	baseline := &v1beta1.SeccompProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "baseline"},
+		Spec: v1beta1.SeccompProfileSpec{Contents: &v1beta1.SeccompProfileJSON{
+			DefaultAction: v1beta1.ActionErr,
+			Syscalls: []v1beta1.SeccompProfileSyscall{{
+				Names:  []string{"read", "write", "ptrace"},
+				Action: v1beta1.ActionAllow,
+			}, {
+				Names:  []string{"mount"},
+				Action: v1beta1.ActionLog,
+			}},
+		}},
+	}
+	spec := &v1beta1.SeccompProfileSpec{
+		BaseProfileRef: &v1beta1.BaseProfileReference{Name: "baseline"},
+		RemoveSyscalls: []string{"ptrace"},
+		Contents: &v1beta1.SeccompProfileJSON{
+			Syscalls: []v1beta1.SeccompProfileSyscall{{
+				Names:  []string{"mount", "io_uring_setup"},
+				Action: v1beta1.ActionAllow,
+			}},
+		},
+	}
+
+	var tracked []string
+	got, err := flatten(context.Background(), listerFor(t, baseline), "app", spec, func(name string) error {
+		tracked = append(tracked, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	want := &v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionErr,
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:  []string{"io_uring_setup", "mount", "read", "write"},
+			Action: v1beta1.ActionAllow,
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("flatten() (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff([]string{"baseline"}, tracked); diff != "" {
+		t.Errorf("tracked (-want +got): %s", diff)
+	}
+}
+
+func TestFlattenDropsDefaultAction(t *testing.T) {
+	plain := &v1beta1.SeccompProfileSpec{Contents: &v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionAllow,
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:  []string{"ptrace"},
+			Action: v1beta1.ActionErr,
+		}},
+	}}
+	redundant := plain.DeepCopy()
+	redundant.Contents.Syscalls = append(redundant.Contents.Syscalls, v1beta1.SeccompProfileSyscall{
+		Names:  []string{"read", "write"},
+		Action: v1beta1.ActionAllow,
+	})
+
+	var got []*v1beta1.SeccompProfileJSON
+	for _, spec := range []*v1beta1.SeccompProfileSpec{plain, redundant} {
+		contents, err := flatten(context.Background(), listerFor(t), "app", spec, func(string) error { return nil })
+		if err != nil {
+			t.Fatalf("flatten: %v", err)
+		}
+		got = append(got, contents)
+	}
+	if diff := cmp.Diff(got[0], got[1]); diff != "" {
+		t.Errorf("flatten() (-plain +redundant): %s", diff)
+	}
+	var hashes []string
+	for _, contents := range got {
+		hash, err := contentHash(contents)
+		if err != nil {
+			t.Fatalf("contentHash: %v", err)
+		}
+		hashes = append(hashes, hash)
+	}
+	if hashes[0] != hashes[1] {
+		t.Errorf("contentHash() = %s and %s, want equal", hashes[0], hashes[1])
+	}
+}
+
+func TestFlattenCycle(t *testing.T) {
+	a := &v1beta1.SeccompProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "a"},
+		Spec:       v1beta1.SeccompProfileSpec{BaseProfileRef: &v1beta1.BaseProfileReference{Name: "b"}},
+	}
+	b := &v1beta1.SeccompProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "b"},
+		Spec:       v1beta1.SeccompProfileSpec{BaseProfileRef: &v1beta1.BaseProfileReference{Name: "a"}},
+	}
+
+	_, err := flatten(context.Background(), listerFor(t, a, b), "a", &a.Spec, func(string) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "a -> b -> a") {
+		t.Errorf("flatten() = %v, want cycle error", err)
+	}
+}
+
+func TestFlattenBuiltin(t *testing.T) {
+	spec := &v1beta1.SeccompProfileSpec{
+		BaseProfileRef: &v1beta1.BaseProfileReference{Builtin: v1beta1.BuiltinRuntimeDefault},
+		Contents: &v1beta1.SeccompProfileJSON{
+			Syscalls: []v1beta1.SeccompProfileSyscall{{
+				Names:  []string{"io_uring_setup", "io_uring_enter", "io_uring_register"},
+				Action: v1beta1.ActionAllow,
+			}},
+		},
+	}
+
+	got, err := flatten(context.Background(), listerFor(t), "app", spec, func(name string) error {
+		t.Errorf("tracked %q, builtin profiles are not tracked", name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("flatten: %v", err)
+	}
+	if got.DefaultAction != v1beta1.ActionErr {
+		t.Errorf("defaultAction = %s, want %s", got.DefaultAction, v1beta1.ActionErr)
+	}
+	allowed := map[string]bool{}
+	for _, s := range got.Syscalls {
+		for _, n := range s.Names {
+			if s.Action == v1beta1.ActionAllow && s.Args == nil && s.Includes == nil {
+				allowed[n] = true
+			}
+		}
+	}
+	for _, n := range []string{"read", "io_uring_setup", "io_uring_enter", "io_uring_register"} {
+		if !allowed[n] {
+			t.Errorf("%s is not allowed unconditionally", n)
+		}
+	}
+}
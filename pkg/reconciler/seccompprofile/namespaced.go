@@ -0,0 +1,39 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+
+	namespacedseccompprofilereconciler "github.com/imjasonh/seccomp-profile/pkg/apis/injection/reconciler/seccomp/v1beta1/namespacedseccompprofile"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"knative.dev/pkg/reconciler"
+)
+
+// NamespacedReconciler implements namespacedseccompprofilereconciler.Interface
+// for NamespacedSeccompProfile resources.
+type NamespacedReconciler struct {
+	profileReconciler
+}
+
+// Check that our NamespacedReconciler implements Interface
+var _ namespacedseccompprofilereconciler.Interface = (*NamespacedReconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *NamespacedReconciler) ReconcileKind(ctx context.Context, p *v1beta1.NamespacedSeccompProfile) reconciler.Event {
+	return r.reconcile(ctx, p, p.Namespace, "", &p.Spec, &p.Status)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	versioned "github.com/imjasonh/seccomp-profile/pkg/apis/clientset/versioned"
+	v1beta1listers "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/system"
+)
+
+// AgentSelector selects the pods of the controller DaemonSet, which write
+// profiles to their nodes.
+const AgentSelector = "seccomp.imjasonh.dev/component=controller"
+
+// nodeStatuses records the result of writing profiles to this node in
+// SeccompProfileNodeStatuses, and summarizes those of every node.
+type nodeStatuses struct {
+	client   versioned.Interface
+	lister   v1beta1listers.SeccompProfileNodeStatusLister
+	nodes    corev1listers.NodeLister
+	agents   corev1listers.PodLister
+	nodeName string
+}
+
+// nodeStatusNamespace returns the namespace of a profile's
+// SeccompProfileNodeStatuses.
+func nodeStatusNamespace(p kmeta.OwnerRefable) string {
+	if ns := p.GetObjectMeta().GetNamespace(); ns != "" {
+		return ns
+	}
+	return system.Namespace()
+}
+
+// nodeStatusName returns the name of a profile's SeccompProfileNodeStatus
+// for a node. The kind is included so that the statuses of cluster-wide
+// profiles don't collide with those of namespaced profiles in the system
+// namespace.
+func nodeStatusName(p kmeta.OwnerRefable, nodeName string) string {
+	kind := strings.ToLower(p.GetGroupVersionKind().Kind)
+	return kmeta.ChildName(kind+"-"+p.GetObjectMeta().GetName(), "-"+nodeName)
+}
+
+// record creates or updates this node's SeccompProfileNodeStatus for the
+// profile, if the hash or error has changed.
+func (s *nodeStatuses) record(ctx context.Context, p kmeta.OwnerRefable, hash string, writeErr error) error {
+	want := &v1beta1.SeccompProfileNodeStatus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            nodeStatusName(p, s.nodeName),
+			Namespace:       nodeStatusNamespace(p),
+			Labels:          map[string]string{v1beta1.ProfileUIDLabel: string(p.GetObjectMeta().GetUID())},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(p)},
+		},
+		NodeName:    s.nodeName,
+		ProfileKind: p.GetGroupVersionKind().Kind,
+		ProfileName: p.GetObjectMeta().GetName(),
+		ContentHash: hash,
+		WriteTime:   metav1.Now(),
+	}
+	if writeErr != nil {
+		want.Error = writeErr.Error()
+	}
+
+	got, err := s.lister.SeccompProfileNodeStatuses(want.Namespace).Get(want.Name)
+	if apierrs.IsNotFound(err) {
+		if _, err := s.client.SeccompV1beta1().SeccompProfileNodeStatuses(want.Namespace).Create(ctx, want, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error creating node status %s: %w", want.Name, err)
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Only update when the result changes, since every update causes
+	// every node to reconcile the profile.
+	if got.ContentHash == want.ContentHash && got.Error == want.Error {
+		return nil
+	}
+	got = got.DeepCopy()
+	got.Labels = want.Labels
+	got.OwnerReferences = want.OwnerReferences
+	got.NodeName = want.NodeName
+	got.ProfileKind = want.ProfileKind
+	got.ProfileName = want.ProfileName
+	got.ContentHash = want.ContentHash
+	got.WriteTime = want.WriteTime
+	got.Error = want.Error
+	if _, err := s.client.SeccompV1beta1().SeccompProfileNodeStatuses(got.Namespace).Update(ctx, got, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating node status %s: %w", got.Name, err)
+	}
+	return nil
+}
+
+// summarize counts the nodes that have written, or failed to write, the
+// profile's current contents, out of those the controller runs on.
+// Statuses of other nodes, such as those that no longer exist, are
+// ignored.
+func (s *nodeStatuses) summarize(p kmeta.OwnerRefable, hash string) (*v1beta1.SeccompProfileDistribution, error) {
+	nodes, err := s.nodes.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	agents, err := s.agents.Pods(system.Namespace()).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	names := agentNodes(nodes, agents)
+
+	selector := labels.SelectorFromSet(labels.Set{v1beta1.ProfileUIDLabel: string(p.GetObjectMeta().GetUID())})
+	statuses, err := s.lister.SeccompProfileNodeStatuses(nodeStatusNamespace(p)).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	return distribution(names, statuses, hash), nil
+}
+
+// agentNodes returns the names of the nodes that have a controller pod,
+// and so write profiles. Nodes it doesn't run on, such as tainted control
+// plane nodes or Windows nodes, never will.
+func agentNodes(nodes []*corev1.Node, agents []*corev1.Pod) sets.String {
+	scheduled := sets.NewString()
+	for _, pod := range agents {
+		if pod.Spec.NodeName != "" {
+			scheduled.Insert(pod.Spec.NodeName)
+		}
+	}
+	out := sets.NewString()
+	for _, n := range nodes {
+		if scheduled.Has(n.Name) {
+			out.Insert(n.Name)
+		}
+	}
+	return out
+}
+
+func distribution(nodes sets.String, statuses []*v1beta1.SeccompProfileNodeStatus, hash string) *v1beta1.SeccompProfileDistribution {
+	d := &v1beta1.SeccompProfileDistribution{Nodes: int32(nodes.Len())}
+	for _, ns := range statuses {
+		if !nodes.Has(ns.NodeName) || ns.ContentHash != hash {
+			continue
+		}
+		if ns.Error != "" {
+			d.Failed++
+		} else {
+			d.Written++
+		}
+	}
+	d.Summary = fmt.Sprintf("%d/%d", d.Written, d.Nodes)
+	return d
+}
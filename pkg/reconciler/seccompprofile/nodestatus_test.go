@@ -0,0 +1,89 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestNodeStatusName(t *testing.T) {
+	cluster := &v1beta1.SeccompProfile{ObjectMeta: metav1.ObjectMeta{Name: "audit"}}
+	namespaced := &v1beta1.NamespacedSeccompProfile{ObjectMeta: metav1.ObjectMeta{Name: "audit", Namespace: "seccomp-profile"}}
+
+	if got, want := nodeStatusName(cluster, "node-1"), "seccompprofile-audit-node-1"; got != want {
+		t.Errorf("nodeStatusName() = %q, want %q", got, want)
+	}
+	if got, want := nodeStatusName(namespaced, "node-1"), "namespacedseccompprofile-audit-node-1"; got != want {
+		t.Errorf("nodeStatusName() = %q, want %q", got, want)
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	status := func(node, hash, err string) *v1beta1.SeccompProfileNodeStatus {
+		return &v1beta1.SeccompProfileNodeStatus{NodeName: node, ContentHash: hash, Error: err}
+	}
+	got := distribution(sets.NewString("a", "b", "c", "d"), []*v1beta1.SeccompProfileNodeStatus{
+		status("a", "sha256:new", ""),
+		status("b", "sha256:new", "disk full"),
+		status("c", "sha256:old", ""),
+		// Statuses of deleted nodes are ignored.
+		status("gone", "sha256:new", ""),
+	}, "sha256:new")
+	want := &v1beta1.SeccompProfileDistribution{
+		Nodes:   4,
+		Written: 1,
+		Failed:  1,
+		Summary: "1/4",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("distribution (-want, +got) = %s", diff)
+	}
+}
+
+func TestAgentNodes(t *testing.T) {
+	node := func(name string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	agent := func(nodeName string) *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{NodeName: nodeName}}
+	}
+	got := agentNodes(
+		// control-plane has no controller pod, such as because of a taint.
+		[]*corev1.Node{node("a"), node("b"), node("control-plane")},
+		// The pod on a deleted node, and one not yet scheduled, are ignored.
+		[]*corev1.Pod{agent("a"), agent("b"), agent("gone"), agent("")},
+	)
+	if diff := cmp.Diff([]string{"a", "b"}, got.List()); diff != "" {
+		t.Errorf("agentNodes (-want, +got) = %s", diff)
+	}
+
+	// Once every node with a controller pod has written the profile, it's
+	// fully distributed.
+	d := distribution(got, []*v1beta1.SeccompProfileNodeStatus{
+		{NodeName: "a", ContentHash: "sha256:new"},
+		{NodeName: "b", ContentHash: "sha256:new"},
+	}, "sha256:new")
+	if d.Written != d.Nodes || d.Summary != "2/2" {
+		t.Errorf("distribution = %+v, want 2/2", d)
+	}
+}
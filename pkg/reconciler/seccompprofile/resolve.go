@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+// nodeInfo describes the node the controller is running on, used to
+// resolve conditional syscall rules.
+type nodeInfo struct {
+	// arch is the node's architecture, using GOARCH names, or empty if it
+	// isn't known, in which case it matches no arches.
+	arch   string
+	kernel v1beta1.KernelVersion
+}
+
+// machineArches maps uname machine names to the GOARCH names used in
+// includes and excludes.
+var machineArches = map[string]string{
+	"x86_64":  "amd64",
+	"i386":    "386",
+	"i686":    "386",
+	"aarch64": "arm64",
+	"armv7l":  "arm",
+	"armv8l":  "arm",
+	"ppc64le": "ppc64le",
+	"ppc64":   "ppc64",
+	"s390x":   "s390x",
+	"riscv64": "riscv64",
+	"mips64":  "mips64",
+}
+
+// defaultCapabilities are the capabilities containerd and Docker give
+// containers by default, which capability conditions are evaluated
+// against. Containers granted more capabilities don't get the syscalls
+// those conditions would allow them.
+var defaultCapabilities = sets.NewString(
+	"CAP_AUDIT_WRITE",
+	"CAP_CHOWN",
+	"CAP_DAC_OVERRIDE",
+	"CAP_FOWNER",
+	"CAP_FSETID",
+	"CAP_KILL",
+	"CAP_MKNOD",
+	"CAP_NET_BIND_SERVICE",
+	"CAP_NET_RAW",
+	"CAP_SETFCAP",
+	"CAP_SETGID",
+	"CAP_SETPCAP",
+	"CAP_SETUID",
+	"CAP_SYS_CHROOT",
+)
+
+// currentNode returns the node's info. An unknown architecture isn't an
+// error, so that profiles are still written on nodes the controller
+// doesn't know; rules conditional on architectures don't apply there.
+func currentNode(ctx context.Context) (nodeInfo, error) {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return nodeInfo{}, fmt.Errorf("uname: %w", err)
+	}
+	machine := unix.ByteSliceToString(uts.Machine[:])
+	arch, ok := machineArches[machine]
+	if !ok {
+		logging.FromContext(ctx).Warnf("Unknown machine architecture %q, so rules conditional on architectures won't apply", machine)
+	}
+	kernel, err := v1beta1.ParseKernelVersion(unix.ByteSliceToString(uts.Release[:]))
+	if err != nil {
+		return nodeInfo{}, err
+	}
+	return nodeInfo{arch: arch, kernel: kernel}, nil
+}
+
+// matches returns true if the node, and a container with the default
+// capabilities, satisfy every condition of the filter.
+func (n nodeInfo) matches(f *v1beta1.SeccompProfileFilter) bool {
+	if len(f.Arches) > 0 && !sets.NewString(f.Arches...).Has(n.arch) {
+		return false
+	}
+	if !defaultCapabilities.HasAll(f.Caps...) {
+		return false
+	}
+	if f.MinKernel != "" {
+		min, err := v1beta1.ParseKernelVersion(f.MinKernel)
+		if err != nil || n.kernel.Less(min) {
+			return false
+		}
+	}
+	return true
+}
+
+// excludedBy returns true if the node, or a container with the default
+// capabilities, matches any condition of the filter.
+func (n nodeInfo) excludedBy(f *v1beta1.SeccompProfileFilter) bool {
+	if sets.NewString(f.Arches...).Has(n.arch) {
+		return true
+	}
+	if defaultCapabilities.HasAny(f.Caps...) {
+		return true
+	}
+	if f.MinKernel != "" {
+		min, err := v1beta1.ParseKernelVersion(f.MinKernel)
+		if err == nil && !n.kernel.Less(min) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve returns a copy of the profile in the format container runtimes
+// read. containerd loads profiles as the OCI runtime spec's LinuxSeccomp,
+// which has no archMap, includes or excludes, and ignores them, so ArchMap
+// is flattened into Architectures, and conditional syscall rules are
+// evaluated against the node and the default capabilities. Rules that
+// don't apply are dropped, and the conditions of the rest are removed.
+func (n nodeInfo) resolve(in *v1beta1.SeccompProfileJSON) *v1beta1.SeccompProfileJSON {
+	out := in.DeepCopy()
+	if len(out.ArchMap) != 0 {
+		out.Architectures = sets.NewString(out.AllArchitectures()...).List()
+		out.ArchMap = nil
+	}
+	syscalls := out.Syscalls
+	out.Syscalls = nil
+	for _, s := range syscalls {
+		if s.Includes != nil && !n.matches(s.Includes) {
+			continue
+		}
+		if s.Excludes != nil && n.excludedBy(s.Excludes) {
+			continue
+		}
+		s.Includes, s.Excludes = nil, nil
+		out.Syscalls = append(out.Syscalls, s)
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/builtin"
+)
+
+func TestResolve(t *testing.T) {
+	in := &v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionErr,
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:  []string{"read"},
+			Action: v1beta1.ActionAllow,
+		}, {
+			Names:    []string{"arch_prctl"},
+			Action:   v1beta1.ActionAllow,
+			Includes: &v1beta1.SeccompProfileFilter{Arches: []string{"amd64", "x32"}},
+		}, {
+			Names:    []string{"sync_file_range2"},
+			Action:   v1beta1.ActionAllow,
+			Includes: &v1beta1.SeccompProfileFilter{Arches: []string{"arm", "arm64"}},
+		}, {
+			Names:    []string{"open_tree"},
+			Action:   v1beta1.ActionAllow,
+			Includes: &v1beta1.SeccompProfileFilter{MinKernel: "5.2"},
+		}, {
+			Names:    []string{"clone3"},
+			Action:   v1beta1.ActionAllow,
+			Includes: &v1beta1.SeccompProfileFilter{MinKernel: "5.3", Caps: []string{"CAP_SYS_ADMIN"}},
+		}, {
+			Names:    []string{"ptrace"},
+			Action:   v1beta1.ActionAllow,
+			Excludes: &v1beta1.SeccompProfileFilter{MinKernel: "4.8"},
+		}},
+	}
+	node := nodeInfo{arch: "amd64", kernel: v1beta1.KernelVersion{Major: 5, Minor: 2}}
+
+	got := node.resolve(in)
+	want := &v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionErr,
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:  []string{"read"},
+			Action: v1beta1.ActionAllow,
+		}, {
+			Names:  []string{"arch_prctl"},
+			Action: v1beta1.ActionAllow,
+		}, {
+			Names:  []string{"open_tree"},
+			Action: v1beta1.ActionAllow,
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resolve() (-want +got): %s", diff)
+	}
+
+	// On an unknown architecture, rules for particular architectures
+	// don't apply.
+	got = nodeInfo{kernel: node.kernel}.resolve(in)
+	want.Syscalls = append(want.Syscalls[:1], want.Syscalls[2:]...)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resolve() on unknown arch (-want +got): %s", diff)
+	}
+
+	// Capability conditions are resolved against the default capabilities.
+	node.kernel = v1beta1.KernelVersion{Major: 6, Minor: 1}
+	got = node.resolve(&v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionErr,
+		ArchMap: []v1beta1.SeccompProfileArchMap{{
+			Architecture:     "SCMP_ARCH_X86_64",
+			SubArchitectures: []string{"SCMP_ARCH_X86", "SCMP_ARCH_X32"},
+		}},
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:    []string{"clone3"},
+			Action:   v1beta1.ActionAllow,
+			Includes: &v1beta1.SeccompProfileFilter{MinKernel: "5.3", Caps: []string{"CAP_SYS_ADMIN"}},
+		}, {
+			Names:    []string{"chroot"},
+			Action:   v1beta1.ActionAllow,
+			Includes: &v1beta1.SeccompProfileFilter{Caps: []string{"CAP_SYS_CHROOT"}},
+		}, {
+			Names:    []string{"clone"},
+			Action:   v1beta1.ActionAllow,
+			Args:     []v1beta1.SeccompProfileArg{{Index: 0, Value: 2114060288, Op: v1beta1.OpMaskedEqual}},
+			Excludes: &v1beta1.SeccompProfileFilter{Caps: []string{"CAP_SYS_ADMIN"}},
+		}, {
+			Names:    []string{"fchown"},
+			Action:   v1beta1.ActionErr,
+			Excludes: &v1beta1.SeccompProfileFilter{Caps: []string{"CAP_CHOWN"}},
+		}},
+	})
+	want = &v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionErr,
+		Architectures: []string{"SCMP_ARCH_X32", "SCMP_ARCH_X86", "SCMP_ARCH_X86_64"},
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:  []string{"chroot"},
+			Action: v1beta1.ActionAllow,
+		}, {
+			Names:  []string{"clone"},
+			Action: v1beta1.ActionAllow,
+			Args:   []v1beta1.SeccompProfileArg{{Index: 0, Value: 2114060288, Op: v1beta1.OpMaskedEqual}},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("resolve() (-want +got): %s", diff)
+	}
+}
+
+// TestResolveRuntimeDefault checks that profiles extending the builtin
+// profile are written without the fields containerd ignores.
+func TestResolveRuntimeDefault(t *testing.T) {
+	contents, err := builtin.Profile(v1beta1.BuiltinRuntimeDefault)
+	if err != nil {
+		t.Fatalf("builtin.Profile: %v", err)
+	}
+	node := nodeInfo{arch: "amd64", kernel: v1beta1.KernelVersion{Major: 6, Minor: 1}}
+	got := node.resolve(contents)
+
+	if len(got.ArchMap) != 0 {
+		t.Errorf("ArchMap = %v, want none", got.ArchMap)
+	}
+	if len(got.Architectures) == 0 {
+		t.Error("Architectures is empty")
+	}
+	sysAdmin := sets.NewString("unshare", "setns", "mount", "bpf")
+	for _, s := range got.Syscalls {
+		if s.Includes != nil || s.Excludes != nil {
+			t.Errorf("rule for %v still has includes %+v or excludes %+v", s.Names, s.Includes, s.Excludes)
+		}
+		if s.Action == v1beta1.ActionAllow && sysAdmin.HasAny(s.Names...) {
+			t.Errorf("rule allows %v, which need CAP_SYS_ADMIN", s.Names)
+		}
+	}
+}
@@ -18,53 +18,92 @@ package seccompprofile
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"os"
 
-	seccompprofilereconciler "github.com/imjasonh/seccomp-profile/pkg/apis/injection/reconciler/seccomp/v1alpha1/seccompprofile"
-	v1alpha1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1alpha1"
+	seccompprofilereconciler "github.com/imjasonh/seccomp-profile/pkg/apis/injection/reconciler/seccomp/v1beta1/seccompprofile"
+	v1beta1listers "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
 )
 
-const path = "/profiles"
-
 // Reconciler implements seccompprofilereconciler.Interface for
 // SeccompProfile resources.
 type Reconciler struct {
+	profileReconciler
 }
 
 // Check that our Reconciler implements Interface
 var _ seccompprofilereconciler.Interface = (*Reconciler)(nil)
 
 // ReconcileKind implements Interface.ReconcileKind.
-func (r *Reconciler) ReconcileKind(ctx context.Context, p *v1alpha1.SeccompProfile) reconciler.Event {
+func (r *Reconciler) ReconcileKind(ctx context.Context, p *v1beta1.SeccompProfile) reconciler.Event {
+	return r.reconcile(ctx, p, "", p.Name, &p.Spec, &p.Status)
+}
+
+// profileReconciler holds what reconciling either kind of profile needs.
+type profileReconciler struct {
+	writer   *Writer
+	statuses *nodeStatuses
+	lister   v1beta1listers.SeccompProfileLister
+	tracker  tracker.Interface
+}
+
+// reconcile writes the profile p, with the given spec and status, to this
+// node and updates its status. namespace is empty for a cluster-wide
+// SeccompProfile, and self is its name, so that it's detected if the
+// profile is its own ancestor.
+func (r *profileReconciler) reconcile(ctx context.Context, p kmeta.OwnerRefableAccessor, namespace, self string, spec *v1beta1.SeccompProfileSpec, status *v1beta1.SeccompProfileStatus) reconciler.Event {
+	name := p.GetName()
 	logger := logging.FromContext(ctx)
-	logger.Infof("reconciling %s", p.Name)
+	if namespace == "" {
+		logger.Infof("reconciling %s", name)
+	} else {
+		logger.Infof("reconciling %s/%s", namespace, name)
+	}
 
 	// Validate again just to be sure.
-	if err := p.Validate(ctx); err != nil {
-		return err
+	if err := spec.Validate(ctx).ViaField("spec").Filter(apis.ErrorLevel); err != nil {
+		status.MarkInvalidContents("%v", err)
+		return reconciler.NewEvent(corev1.EventTypeWarning, v1beta1.ReasonInvalidContents, "Invalid profile: %v", err)
 	}
 
-	// Write policy contents to localhost.
-	fn := fmt.Sprintf("%s/%s.json", path, p.Name)
-	logger.Infof("writing %s", fn)
-	f, err := os.Create(fn)
+	contents, err := flatten(ctx, r.lister, self, spec, func(name string) error {
+		return r.tracker.TrackReference(baseProfileReference(name), p)
+	})
 	if err != nil {
-		return fmt.Errorf("error creating %s: %w", fn, err)
+		status.MarkInvalidContents("%v", err)
+		return reconciler.NewEvent(corev1.EventTypeWarning, v1beta1.ReasonInvalidContents, "Invalid profile: %v", err)
 	}
-	defer f.Close()
-	if err := json.NewEncoder(f).Encode(&p.Spec.Contents); err != nil {
-		return fmt.Errorf("error writing %s: %w", fn, err)
+
+	if spec.TranslateSyscalls {
+		translateSyscalls(ctx, p, status, contents)
+	} else {
+		status.TranslatedSyscalls = nil
 	}
-	logger.Infof("wrote %s", fn)
 
-	if err := listFiles(ctx); err != nil {
-		return fmt.Errorf("error listing files after write: %w", err)
+	hash, err := contentHash(contents)
+	if err != nil {
+		return err
+	}
+
+	cfg := config.FromContextOrDefaults(ctx)
+	drifted, writeErr := r.writer.write(ctx, p, hash, contents)
+	if err := r.statuses.record(ctx, p, hash, writeErr); err != nil {
+		return err
+	}
+	if writeErr == nil && drifted {
+		reportDrift(ctx, p, profilePath(cfg, namespace, name), r.statuses.nodeName)
 	}
 
-	// TODO: Detect deleted policies and delete files.
-	return nil
+	d, err := r.statuses.summarize(p, hash)
+	if err != nil {
+		return err
+	}
+	status.PropagateDistribution(hash, cfg.LocalhostProfile(namespace, name), d)
+	return writeErr
 }
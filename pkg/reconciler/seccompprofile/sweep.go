@@ -0,0 +1,229 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	v1beta1listers "github.com/imjasonh/seccomp-profile/pkg/apis/listers/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+)
+
+// sweepInterval is how often files of deleted profiles are looked for, in
+// addition to when profiles are deleted.
+const sweepInterval = 5 * time.Minute
+
+// Legacy annotations pods used to reference seccomp profiles before the
+// seccompProfile field.
+const (
+	podAnnotation       = "seccomp.security.alpha.kubernetes.io/pod"
+	containerAnnotation = "container.seccomp.security.alpha.kubernetes.io/"
+	localhostPrefix     = "localhost/"
+)
+
+// sweeper removes the files of deleted profiles from the node.
+//
+// Deletion is handled by sweeping rather than finalizing, since every node
+// has to remove its own copy, and a finalizer can only be removed once.
+type sweeper struct {
+	kubeclient kubernetes.Interface
+	profiles   v1beta1listers.SeccompProfileLister
+	namespaced v1beta1listers.NamespacedSeccompProfileLister
+	config     func() *config.Config
+	nodeName   string
+
+	trigger chan struct{}
+}
+
+func newSweeper(kubeclient kubernetes.Interface, profiles v1beta1listers.SeccompProfileLister, namespaced v1beta1listers.NamespacedSeccompProfileLister, config func() *config.Config, nodeName string) *sweeper {
+	return &sweeper{
+		kubeclient: kubeclient,
+		profiles:   profiles,
+		namespaced: namespaced,
+		config:     config,
+		nodeName:   nodeName,
+		trigger:    make(chan struct{}, 1),
+	}
+}
+
+// Trigger requests a sweep soon, such as when a profile is deleted.
+func (s *sweeper) Trigger(interface{}) {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Run sweeps once after hasSynced returns true, and then on every trigger
+// and every sweepInterval until the context is done.
+func (s *sweeper) Run(ctx context.Context, hasSynced func() bool) {
+	logger := logging.FromContext(ctx)
+
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for !hasSynced() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+	for {
+		if err := s.sweep(ctx); err != nil {
+			logger.Errorw("Failed to remove files of deleted profiles", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.trigger:
+		}
+	}
+}
+
+func (s *sweeper) sweep(ctx context.Context) error {
+	cfg := s.config()
+	pods, err := s.kubeclient.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", s.nodeName).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing pods on %s: %w", s.nodeName, err)
+	}
+
+	dir := profilesDir(cfg)
+	return removeOrphans(ctx, dir, indexIn(dir), func() (sets.String, error) { return s.wanted(cfg) }, inUse(pods.Items, cfg.Directory))
+}
+
+// wanted returns the files, relative to the profiles directory, of the
+// profiles that exist.
+func (s *sweeper) wanted(cfg *config.Config) (sets.String, error) {
+	out := sets.NewString()
+	profiles, err := s.profiles.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range profiles {
+		out.Insert(cfg.File("", p.Name))
+	}
+	namespaced, err := s.namespaced.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range namespaced {
+		out.Insert(cfg.File(p.Namespace, p.Name))
+	}
+	return out, nil
+}
+
+// inUse returns the files, relative to the profiles directory, referenced
+// by pods that are still running. directory is the profiles directory
+// relative to the kubelet's seccomp root.
+func inUse(pods []corev1.Pod, directory string) sets.String {
+	out := sets.NewString()
+	add := func(localhostProfile string) {
+		if rel, err := filepath.Rel(directory, filepath.Clean(localhostProfile)); err == nil && !strings.HasPrefix(rel, "..") {
+			out.Insert(rel)
+		}
+	}
+	addContext := func(sc *corev1.SeccompProfile) {
+		if sc != nil && sc.Type == corev1.SeccompProfileTypeLocalhost && sc.LocalhostProfile != nil {
+			add(*sc.LocalhostProfile)
+		}
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if sc := pod.Spec.SecurityContext; sc != nil {
+			addContext(sc.SeccompProfile)
+		}
+		for _, cs := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, c := range cs {
+				if c.SecurityContext != nil {
+					addContext(c.SecurityContext.SeccompProfile)
+				}
+			}
+		}
+		for _, c := range pod.Spec.EphemeralContainers {
+			if c.SecurityContext != nil {
+				addContext(c.SecurityContext.SeccompProfile)
+			}
+		}
+		for k, v := range pod.Annotations {
+			if (k == podAnnotation || strings.HasPrefix(k, containerAnnotation)) && strings.HasPrefix(v, localhostPrefix) {
+				add(strings.TrimPrefix(v, localhostPrefix))
+			}
+		}
+	}
+	return out
+}
+
+// removeOrphans removes the files in the index that aren't wanted or in
+// use, and then any namespace directories they leave empty. Files that
+// aren't in the index are never removed.
+//
+// The wanted files are listed again before each file is removed, since a
+// profile created after the index was read has its file written and
+// indexed by the reconciler, which would otherwise race with the sweep.
+func removeOrphans(ctx context.Context, root string, idx *index, wanted func() (sets.String, error), inUse sets.String) error {
+	logger := logging.FromContext(ctx)
+
+	for _, rel := range idx.files(ctx) {
+		fn := filepath.Join(root, rel)
+		if inUse.Has(rel) {
+			logger.Infof("keeping %s of deleted profile, which is in use", fn)
+			continue
+		}
+		w, err := wanted()
+		if err != nil {
+			return err
+		}
+		if w.Has(rel) {
+			continue
+		}
+		logger.Infof("removing %s of deleted profile", fn)
+		if err := os.Remove(fn); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing %s: %w", fn, err)
+		}
+		if err := idx.remove(ctx, rel); err != nil {
+			return err
+		}
+
+		if dir := filepath.Dir(fn); dir != root {
+			if fis, err := os.ReadDir(dir); err == nil && len(fis) == 0 {
+				logger.Infof("removing empty %s", dir)
+				if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("error removing %s: %w", dir, err)
+				}
+			}
+		}
+	}
+	return nil
+}
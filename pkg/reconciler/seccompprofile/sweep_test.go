@@ -0,0 +1,128 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestInUse(t *testing.T) {
+	localhost := func(p string) *corev1.SeccompProfile {
+		return &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: &p}
+	}
+	pods := []corev1.Pod{{
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{SeccompProfile: localhost("profiles/audit.json")},
+			Containers: []corev1.Container{{
+				SecurityContext: &corev1.SecurityContext{SeccompProfile: localhost("profiles/team-a/strict.json")},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			"container.seccomp.security.alpha.kubernetes.io/app": "localhost/profiles/legacy.json",
+		}},
+		Status: corev1.PodStatus{Phase: corev1.PodPending},
+	}, {
+		// Finished pods don't keep files.
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{SeccompProfile: localhost("profiles/done.json")},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+	}, {
+		// Nor do profiles this controller doesn't write.
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{SeccompProfile: localhost("other/profile.json")},
+		},
+	}}
+
+	got := inUse(pods, "profiles").List()
+	want := []string{"audit.json", "legacy.json", "team-a/strict.json"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("inUse (-want, +got) = %s", diff)
+	}
+}
+
+func TestRemoveOrphans(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	idx := newIndex(filepath.Join(root, indexFile))
+	for _, rel := range []string{
+		"wanted.json",
+		"deleted.json",
+		"in-use.json",
+		"unowned.json",
+		"team-a/wanted.json",
+		"team-b/deleted.json",
+		"new.json",
+	} {
+		fn := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fn, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if rel != "unowned.json" {
+			if err := idx.record(ctx, indexEntry{File: rel}, true); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// Files already gone are removed from the index too.
+	if err := idx.record(ctx, indexEntry{File: "missing.json"}, true); err != nil {
+		t.Fatal(err)
+	}
+
+	// new.json's profile is created after the sweep starts.
+	wanted := sets.NewString("wanted.json", "team-a/wanted.json")
+	listWanted := func() (sets.String, error) {
+		out := sets.NewString(wanted.List()...)
+		wanted.Insert("new.json")
+		return out, nil
+	}
+	if err := removeOrphans(ctx, root, idx, listWanted, sets.NewString("in-use.json")); err != nil {
+		t.Fatalf("removeOrphans: %v", err)
+	}
+
+	var got []string
+	if err := filepath.WalkDir(root, func(fn string, _ os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, fn)
+		got = append(got, rel)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{".", indexFile, "in-use.json", "new.json", "team-a", "team-a/wanted.json", "unowned.json", "wanted.json"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("files (-want, +got) = %s", diff)
+	}
+	if diff := cmp.Diff([]string{"in-use.json", "new.json", "team-a/wanted.json", "wanted.json"}, idx.files(ctx)); diff != "" {
+		t.Errorf("index (-want, +got) = %s", diff)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/syscalls"
+)
+
+// translateSyscalls translates the profile's syscalls for its other
+// architectures, and records what was added in status. Every node does
+// this, so the event on obj is only sent when what was added changes.
+func translateSyscalls(ctx context.Context, obj runtime.Object, status *v1beta1.SeccompProfileStatus, contents *v1beta1.SeccompProfileJSON) {
+	added := translate(contents)
+	previous := status.TranslatedSyscalls
+	status.TranslatedSyscalls = added
+	if len(added) == 0 || equality.Semantic.DeepEqual(previous, added) {
+		return
+	}
+	msg := fmt.Sprintf("Added syscalls for other architectures: %s", strings.Join(added, ", "))
+	logging.FromContext(ctx).Info(msg)
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Event(obj, corev1.EventTypeNormal, "SyscallsTranslated", msg)
+	}
+}
+
+// translate adds to each rule the equivalents of its syscalls on the
+// profile's architectures where they don't exist, or on every architecture
+// with a syscall table if none are listed. Syscalls already named anywhere
+// in the profile aren't added, so that rules for them aren't contradicted.
+// It returns a description of each syscall added.
+//
+// Only unconditional rules that allow or log their syscalls are translated.
+// The arguments of equivalents don't line up (open's flags are its second
+// argument, but openat's are its third), and denying an equivalent can
+// deny much more than the original did, such as clone for fork.
+func translate(c *v1beta1.SeccompProfileJSON) []string {
+	arches := c.AllArchitectures()
+	if len(arches) == 0 {
+		arches = syscalls.Architectures()
+	}
+
+	named := sets.NewString()
+	for _, s := range c.Syscalls {
+		named.Insert(s.Names...)
+	}
+
+	var added []string
+	for i, s := range c.Syscalls {
+		if !translatable(s) {
+			continue
+		}
+		names := sets.NewString(s.Names...)
+		for _, n := range s.Names {
+			for _, arch := range arches {
+				for _, e := range syscalls.Equivalents(n, arch) {
+					if named.Has(e) {
+						continue
+					}
+					named.Insert(e)
+					names.Insert(e)
+					added = append(added, fmt.Sprintf("%s (for %s)", e, n))
+				}
+			}
+		}
+		c.Syscalls[i].Names = names.List()
+	}
+	return added
+}
+
+// translatable returns true if the rule's equivalents can be added to it.
+func translatable(s v1beta1.SeccompProfileSyscall) bool {
+	if len(s.Args) != 0 || s.Includes != nil || s.Excludes != nil {
+		return false
+	}
+	return s.Action == v1beta1.ActionAllow || s.Action == v1beta1.ActionLog
+}
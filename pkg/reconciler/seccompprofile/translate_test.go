@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+func TestTranslate(t *testing.T) {
+	c := &v1beta1.SeccompProfileJSON{
+		DefaultAction: v1beta1.ActionErr,
+		Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_AARCH64"},
+		Syscalls: []v1beta1.SeccompProfileSyscall{{
+			Names:  []string{"dup2", "open", "read"},
+			Action: v1beta1.ActionAllow,
+		}, {
+			// dup3 is already named, so it isn't added for dup2 above.
+			Names:  []string{"dup3"},
+			Action: v1beta1.ActionLog,
+		}, {
+			// The equivalents' arguments are at other indices, so none are added.
+			Names:  []string{"creat", "access"},
+			Action: v1beta1.ActionAllow,
+			Args:   []v1beta1.SeccompProfileArg{{Index: 1, Value: 0, Op: v1beta1.OpEqualTo}},
+		}, {
+			// Denying clone for fork would break threads.
+			Names:  []string{"fork", "vfork"},
+			Action: v1beta1.ActionErr,
+		}},
+	}
+
+	added := translate(c)
+	if diff := cmp.Diff([]string{"openat (for open)"}, added); diff != "" {
+		t.Errorf("translate() added (-want +got): %s", diff)
+	}
+	want := []v1beta1.SeccompProfileSyscall{{
+		Names:  []string{"dup2", "open", "openat", "read"},
+		Action: v1beta1.ActionAllow,
+	}, {
+		Names:  []string{"dup3"},
+		Action: v1beta1.ActionLog,
+	}, {
+		Names:  []string{"creat", "access"},
+		Action: v1beta1.ActionAllow,
+		Args:   []v1beta1.SeccompProfileArg{{Index: 1, Value: 0, Op: v1beta1.OpEqualTo}},
+	}, {
+		Names:  []string{"fork", "vfork"},
+		Action: v1beta1.ActionErr,
+	}}
+	if diff := cmp.Diff(want, c.Syscalls); diff != "" {
+		t.Errorf("translate() syscalls (-want +got): %s", diff)
+	}
+}
+
+func TestTranslateSyscallsEventOnce(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+	p := &v1beta1.SeccompProfile{}
+	contents := func() *v1beta1.SeccompProfileJSON {
+		return &v1beta1.SeccompProfileJSON{
+			DefaultAction: v1beta1.ActionErr,
+			Architectures: []string{"SCMP_ARCH_X86_64", "SCMP_ARCH_AARCH64"},
+			Syscalls: []v1beta1.SeccompProfileSyscall{{
+				Names:  []string{"open"},
+				Action: v1beta1.ActionAllow,
+			}},
+		}
+	}
+
+	// Each node reconciles the profile, but only the first reports it.
+	for i := 0; i < 3; i++ {
+		translateSyscalls(ctx, p, &p.Status, contents())
+	}
+	if diff := cmp.Diff([]string{"openat (for open)"}, p.Status.TranslatedSyscalls); diff != "" {
+		t.Errorf("TranslatedSyscalls (-want +got): %s", diff)
+	}
+	if got := len(recorder.Events); got != 1 {
+		t.Errorf("got %d events, want 1", got)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+)
+
+// root is where the kubelet's seccomp root is mounted, which pods'
+// localhostProfiles are relative to.
+const root = "/seccomp"
+
+// dirMode is the mode of the directories profiles are written to, which
+// the kubelet and container runtime only need to read.
+const dirMode os.FileMode = 0755
+
+// Writer writes profiles to the node. One Writer is shared by the
+// controllers of cluster-wide and namespaced profiles, so that the node's
+// files are watched for drift once.
+type Writer struct {
+	node nodeInfo
+
+	// mu guards written and the enqueue functions, and is held while
+	// writing, so that drift isn't reported for a file that's being
+	// written.
+	mu sync.Mutex
+	// written maps the files written to what they were written with, to
+	// detect when they're modified or removed by something else.
+	written map[string]writtenFile
+
+	// enqueueCluster and enqueueNamespaced enqueue cluster-wide and
+	// namespaced profiles whose files have drifted.
+	enqueueCluster, enqueueNamespaced func(namespace, name string)
+	watchOnce                         sync.Once
+}
+
+// NewWriter returns a Writer for the node the controller is running on.
+func NewWriter(ctx context.Context) (*Writer, error) {
+	node, err := currentNode(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node info: %w", err)
+	}
+	logging.FromContext(ctx).Infof("Running on %s, kernel %d.%d", node.arch, node.kernel.Major, node.kernel.Minor)
+	return &Writer{node: node}, nil
+}
+
+// writtenFile records which profile a file was written for, and the hash
+// and mode it was written with.
+type writtenFile struct {
+	namespace, name string
+	hash            [sha256.Size]byte
+	mode            os.FileMode
+}
+
+// profilesDir returns the directory profiles are written to.
+func profilesDir(cfg *config.Config) string {
+	return filepath.Join(root, cfg.Directory)
+}
+
+// profilePath returns the path of a profile's file.
+func profilePath(cfg *config.Config, namespace, name string) string {
+	return filepath.Join(profilesDir(cfg), cfg.File(namespace, name))
+}
+
+// contentHash returns the hash of a profile's contents.
+func contentHash(contents *v1beta1.SeccompProfileJSON) (string, error) {
+	b, err := json.Marshal(contents)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(b)), nil
+}
+
+// write resolves the profile for this node, writes it and records it in
+// the index. It returns true if the file had drifted: modified or removed
+// since it was last written.
+func (w *Writer) write(ctx context.Context, p kmeta.OwnerRefable, hash string, contents *v1beta1.SeccompProfileJSON) (bool, error) {
+	// Resolve conditional rules for this node.
+	contents = w.node.resolve(contents)
+
+	b, err := json.Marshal(contents)
+	if err != nil {
+		return false, err
+	}
+	b = append(b, '\n')
+	meta := p.GetObjectMeta()
+	cfg := config.FromContextOrDefaults(ctx)
+	fn := profilePath(cfg, meta.GetNamespace(), meta.GetName())
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	drifted := w.driftedLocked(fn)
+	written, err := writeFile(ctx, fn, b, cfg.FileMode)
+	if err != nil {
+		return drifted, err
+	}
+	if w.written == nil {
+		w.written = make(map[string]writtenFile)
+	}
+	// The file may have moved, if the layout was reconfigured.
+	w.forgetLocked(meta.GetNamespace(), meta.GetName())
+	w.written[fn] = writtenFile{namespace: meta.GetNamespace(), name: meta.GetName(), hash: sha256.Sum256(b), mode: cfg.FileMode}
+
+	return drifted, indexIn(profilesDir(cfg)).record(ctx, indexEntry{
+		File:        cfg.File(meta.GetNamespace(), meta.GetName()),
+		Kind:        p.GetGroupVersionKind().Kind,
+		Namespace:   meta.GetNamespace(),
+		Name:        meta.GetName(),
+		UID:         meta.GetUID(),
+		Generation:  meta.GetGeneration(),
+		ContentHash: hash,
+		WriteTime:   metav1.Now(),
+	}, written)
+}
+
+// forget stops detecting drift of a profile's file, once it's deleted.
+func (w *Writer) forget(namespace, name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.forgetLocked(namespace, name)
+}
+
+func (w *Writer) forgetLocked(namespace, name string) {
+	for fn, f := range w.written {
+		if f.namespace == namespace && f.name == name {
+			delete(w.written, fn)
+		}
+	}
+}
+
+// drifted returns the files that have been modified or removed since they
+// were written.
+func (w *Writer) drifted() []writtenFile {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var out []writtenFile
+	for fn, f := range w.written {
+		if w.driftedLocked(fn) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func (w *Writer) driftedLocked(fn string) bool {
+	f, ok := w.written[fn]
+	if !ok {
+		return false
+	}
+	if fi, err := os.Stat(fn); err != nil || fi.Mode() != f.mode {
+		return true
+	}
+	b, err := os.ReadFile(fn)
+	return err != nil || sha256.Sum256(b) != f.hash
+}
+
+// writeFile replaces the file with b and the mode, unless it already has
+// them, and returns whether it did. The contents are written
+// to a temporary file in the same directory, which is then renamed over the
+// file, so that containers starting at the same time never read a
+// partially written profile.
+func writeFile(ctx context.Context, fn string, b []byte, mode os.FileMode) (bool, error) {
+	logger := logging.FromContext(ctx)
+
+	if fi, err := os.Stat(fn); err == nil && fi.Mode() == mode {
+		if cur, err := os.ReadFile(fn); err == nil && sha256.Sum256(cur) == sha256.Sum256(b) {
+			logger.Debugf("%s is up to date", fn)
+			return false, nil
+		}
+	}
+
+	dir := filepath.Dir(fn)
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return false, fmt.Errorf("error creating directory for %s: %w", fn, err)
+	}
+	logger.Infof("writing %s", fn)
+
+	// The temporary file is hidden and doesn't end in .json, so it's never
+	// mistaken for a profile.
+	f, err := os.CreateTemp(dir, "."+filepath.Base(fn)+".tmp-*")
+	if err != nil {
+		return false, fmt.Errorf("error creating temporary file for %s: %w", fn, err)
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp) // Fails harmlessly once renamed.
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return false, fmt.Errorf("error writing %s: %w", tmp, err)
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		return false, fmt.Errorf("error setting mode of %s: %w", tmp, err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return false, fmt.Errorf("error syncing %s: %w", tmp, err)
+	}
+	if err := f.Close(); err != nil {
+		return false, fmt.Errorf("error closing %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, fn); err != nil {
+		return false, fmt.Errorf("error renaming %s to %s: %w", tmp, fn, err)
+	}
+
+	// Sync the directory so that the rename survives a crash.
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+	logger.Infof("wrote %s", fn)
+	return true, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package seccompprofile
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	v1beta1 "github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
+)
+
+func TestContentHash(t *testing.T) {
+	a := &v1beta1.SeccompProfileJSON{DefaultAction: v1beta1.ActionLog}
+	b := &v1beta1.SeccompProfileJSON{DefaultAction: v1beta1.ActionErr}
+
+	ha, err := contentHash(a)
+	if err != nil {
+		t.Fatalf("contentHash: %v", err)
+	}
+	if again, _ := contentHash(a.DeepCopy()); again != ha {
+		t.Errorf("contentHash() = %q, then %q", ha, again)
+	}
+	if hb, _ := contentHash(b); hb == ha {
+		t.Errorf("contentHash() = %q for different contents", hb)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	ctx := context.Background()
+	fn := filepath.Join(t.TempDir(), "team-a", "audit.json")
+
+	if _, err := writeFile(ctx, fn, []byte("{}\n"), config.DefaultFileMode); err != nil {
+		t.Fatalf("writeFile: %v", err)
+	}
+	before, err := os.Stat(fn)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if before.Mode() != config.DefaultFileMode {
+		t.Errorf("mode = %v, want %v", before.Mode(), config.DefaultFileMode)
+	}
+
+	// Writing the same contents leaves the file alone.
+	if _, err := writeFile(ctx, fn, []byte("{}\n"), config.DefaultFileMode); err != nil {
+		t.Fatalf("writeFile: %v", err)
+	}
+	if after, err := os.Stat(fn); err != nil {
+		t.Fatalf("Stat: %v", err)
+	} else if !os.SameFile(before, after) {
+		t.Error("writeFile replaced a file that was up to date")
+	}
+
+	// Different contents replace it, without leaving temporary files.
+	if _, err := writeFile(ctx, fn, []byte("{\"defaultAction\":\"SCMP_ACT_LOG\"}\n"), config.DefaultFileMode); err != nil {
+		t.Fatalf("writeFile: %v", err)
+	}
+	if b, err := os.ReadFile(fn); err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	} else if got, want := string(b), "{\"defaultAction\":\"SCMP_ACT_LOG\"}\n"; got != want {
+		t.Errorf("contents = %q, want %q", got, want)
+	}
+	if fis, err := os.ReadDir(filepath.Dir(fn)); err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	} else if len(fis) != 1 {
+		t.Errorf("ReadDir() = %d files, want 1", len(fis))
+	}
+}
+
+func TestDrifted(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	b := []byte("{}\n")
+
+	w := &Writer{written: map[string]writtenFile{}}
+	for _, name := range []string{"untouched", "modified", "removed", "chmodded"} {
+		fn := filepath.Join(dir, name+".json")
+		if _, err := writeFile(ctx, fn, b, config.DefaultFileMode); err != nil {
+			t.Fatalf("writeFile: %v", err)
+		}
+		w.written[fn] = writtenFile{name: name, hash: sha256.Sum256(b), mode: config.DefaultFileMode}
+	}
+	if got := w.drifted(); len(got) != 0 {
+		t.Errorf("drifted() = %v, want none", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "modified.json"), []byte("{\"defaultAction\":\"SCMP_ACT_ALLOW\"}\n"), config.DefaultFileMode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "removed.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(dir, "chmodded.json"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, f := range w.drifted() {
+		got = append(got, f.name)
+	}
+	sort.Strings(got)
+	if diff := cmp.Diff([]string{"chmodded", "modified", "removed"}, got); diff != "" {
+		t.Errorf("drifted (-want, +got) = %s", diff)
+	}
+}
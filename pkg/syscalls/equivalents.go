@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syscalls
+
+// equivalents maps syscalls to those that libc uses in their place on
+// architectures where they don't exist. Newer architectures like arm64 and
+// riscv64 only have the *at variants of many path-based syscalls, and
+// 32-bit architectures have 64-bit file offset, 32-bit UID and 64-bit time
+// variants of many others.
+var equivalents = map[string][]string{
+	// Legacy syscalls replaced by more general ones.
+	"access":       {"faccessat", "faccessat2"},
+	"alarm":        {"setitimer"},
+	"chmod":        {"fchmodat"},
+	"chown":        {"fchownat", "chown32"},
+	"creat":        {"openat"},
+	"dup2":         {"dup3"},
+	"epoll_create": {"epoll_create1"},
+	"epoll_wait":   {"epoll_pwait"},
+	"eventfd":      {"eventfd2"},
+	"fork":         {"clone"},
+	"futimesat":    {"utimensat"},
+	"getdents":     {"getdents64"},
+	"getpgrp":      {"getpgid"},
+	"inotify_init": {"inotify_init1"},
+	"lchown":       {"fchownat", "lchown32"},
+	"link":         {"linkat"},
+	"lstat":        {"newfstatat", "lstat64", "fstatat64"},
+	"mkdir":        {"mkdirat"},
+	"mknod":        {"mknodat"},
+	"open":         {"openat"},
+	"pipe":         {"pipe2"},
+	"poll":         {"ppoll"},
+	"readlink":     {"readlinkat"},
+	"rename":       {"renameat", "renameat2"},
+	"rmdir":        {"unlinkat"},
+	"select":       {"pselect6", "_newselect"},
+	"signalfd":     {"signalfd4"},
+	"stat":         {"newfstatat", "stat64", "fstatat64"},
+	"symlink":      {"symlinkat"},
+	"time":         {"clock_gettime"},
+	"unlink":       {"unlinkat"},
+	"utime":        {"utimensat"},
+	"utimes":       {"utimensat"},
+	"vfork":        {"clone"},
+
+	// 64-bit file offsets on 32-bit architectures.
+	"fcntl":      {"fcntl64"},
+	"fstat":      {"fstat64"},
+	"fstatfs":    {"fstatfs64"},
+	"ftruncate":  {"ftruncate64"},
+	"lseek":      {"_llseek"},
+	"mmap":       {"mmap2"},
+	"newfstatat": {"fstatat64"},
+	"sendfile":   {"sendfile64"},
+	"statfs":     {"statfs64"},
+	"truncate":   {"truncate64"},
+
+	// 32-bit UIDs and GIDs on 32-bit architectures.
+	"fchown":    {"fchown32"},
+	"getegid":   {"getegid32"},
+	"geteuid":   {"geteuid32"},
+	"getgid":    {"getgid32"},
+	"getgroups": {"getgroups32"},
+	"getresgid": {"getresgid32"},
+	"getresuid": {"getresuid32"},
+	"getuid":    {"getuid32"},
+	"setfsgid":  {"setfsgid32"},
+	"setfsuid":  {"setfsuid32"},
+	"setgid":    {"setgid32"},
+	"setgroups": {"setgroups32"},
+	"setregid":  {"setregid32"},
+	"setresgid": {"setresgid32"},
+	"setresuid": {"setresuid32"},
+	"setreuid":  {"setreuid32"},
+	"setuid":    {"setuid32"},
+
+	// 64-bit time on 32-bit architectures.
+	"clock_gettime":   {"clock_gettime64"},
+	"clock_nanosleep": {"clock_nanosleep_time64"},
+	"futex":           {"futex_time64"},
+	"ppoll":           {"ppoll_time64"},
+	"pselect6":        {"pselect6_time64"},
+	"recvmmsg":        {"recvmmsg_time64"},
+	"rt_sigtimedwait": {"rt_sigtimedwait_time64"},
+	"timerfd_gettime": {"timerfd_gettime64"},
+	"timerfd_settime": {"timerfd_settime64"},
+	"utimensat":       {"utimensat_time64"},
+}
+
+// Equivalents returns the syscalls used in place of name on the
+// architecture, if name doesn't exist there. It returns nothing if name
+// exists on the architecture, or if there is no table for it.
+func Equivalents(name, arch string) []string {
+	table, ok := tables[arch]
+	if !ok || table.Has(name) {
+		return nil
+	}
+	var out []string
+	for _, e := range equivalents[name] {
+		if table.Has(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package syscalls holds the names of the syscalls on each architecture
+// that profiles are commonly written for.
+//
+// The tables are generated by hack/update-syscalls.sh.
+package syscalls
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+//go:embed tables/*.txt
+var files embed.FS
+
+// tableFiles maps libseccomp architecture names to their tables.
+var tableFiles = map[string]string{
+	"SCMP_ARCH_X86_64":  "x86_64",
+	"SCMP_ARCH_X86":     "x86",
+	"SCMP_ARCH_X32":     "x32",
+	"SCMP_ARCH_AARCH64": "aarch64",
+	"SCMP_ARCH_ARM":     "arm",
+	"SCMP_ARCH_RISCV64": "riscv64",
+	"SCMP_ARCH_S390X":   "s390x",
+	"SCMP_ARCH_PPC64LE": "ppc64le",
+}
+
+var tables = mustLoad()
+
+func mustLoad() map[string]sets.String {
+	out := make(map[string]sets.String, len(tableFiles))
+	for arch, name := range tableFiles {
+		b, err := files.ReadFile(fmt.Sprintf("tables/%s.txt", name))
+		if err != nil {
+			panic(err)
+		}
+		out[arch] = sets.NewString(strings.Fields(string(b))...)
+	}
+	return out
+}
+
+// Architectures returns the libseccomp names of the architectures that have
+// syscall tables, sorted.
+func Architectures() []string {
+	out := make([]string, 0, len(tables))
+	for arch := range tables {
+		out = append(out, arch)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Known returns the syscalls on the architecture, which is named as it is
+// by libseccomp, such as SCMP_ARCH_X86_64. It returns false if there is no
+// table for the architecture. The returned set must not be modified.
+func Known(arch string) (sets.String, bool) {
+	t, ok := tables[arch]
+	return t, ok
+}
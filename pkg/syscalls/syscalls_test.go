@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package syscalls
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTables(t *testing.T) {
+	for _, arch := range Architectures() {
+		table, _ := Known(arch)
+		for _, n := range []string{"read", "write", "openat", "exit_group"} {
+			if !table.Has(n) {
+				t.Errorf("%s is missing %s", arch, n)
+			}
+		}
+	}
+}
+
+func TestEquivalents(t *testing.T) {
+	// Every equivalent must exist somewhere.
+	for name, eqs := range equivalents {
+		for _, e := range eqs {
+			found := false
+			for _, table := range tables {
+				if table.Has(e) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("equivalent %s of %s is not a syscall on any architecture", e, name)
+			}
+		}
+	}
+
+	for _, c := range []struct {
+		name, arch string
+		want       []string
+	}{
+		{"open", "SCMP_ARCH_AARCH64", []string{"openat"}},
+		{"open", "SCMP_ARCH_X86_64", nil},
+		{"stat", "SCMP_ARCH_RISCV64", []string{"newfstatat"}},
+		{"mmap", "SCMP_ARCH_ARM", []string{"mmap2"}},
+		{"open", "SCMP_ARCH_MIPS", nil},
+	} {
+		if diff := cmp.Diff(c.want, Equivalents(c.name, c.arch)); diff != "" {
+			t.Errorf("Equivalents(%s, %s) (-want +got): %s", c.name, c.arch, diff)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"knative.dev/pkg/logging"
+)
+
+// Whiteouts mark files and directories removed from lower layers.
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// labelProfile returns the profile declared by the label in the image's
+// config, or nil if it has none.
+func labelProfile(ctx context.Context, digest name.Digest, desc *remote.Descriptor) (*declaredProfile, error) {
+	img, err := desc.Image()
+	if err != nil {
+		return nil, err
+	}
+	cf, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get config of %s: %w", digest.DigestStr(), err)
+	}
+	v, ok := cf.Config.Labels[profileKey]
+	if !ok {
+		return nil, nil
+	}
+	logging.FromContext(ctx).Debugf("Image %s specified a seccomp profile in a label", digest.DigestStr())
+	return parseProfile(ctx, digest.DigestStr(), []byte(v))
+}
+
+// maxLinks is how many symlinks and hard links are followed to find the
+// profile file.
+const maxLinks = 8
+
+// fileProfile returns the profile in the file in the image's filesystem,
+// or nil if there's no such file.
+//
+// Layers are read from the top, since the topmost copy of the file is the
+// one containers see, until the file is found or removed. Symlinks and hard
+// links to the file are followed, but not symlinked directories in its
+// path. The compressed size of each layer read is taken from the registry's
+// layer budget, shared by the images of the pod, and once the budget is
+// spent the image is treated as declaring no profile, so that admission
+// isn't held up by large images.
+func (r *registry) fileProfile(ctx context.Context, digest name.Digest, desc *remote.Descriptor, file string) (*declaredProfile, error) {
+	logger := logging.FromContext(ctx)
+
+	img, err := desc.Image()
+	if err != nil {
+		return nil, err
+	}
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get layers of %s: %w", digest.DigestStr(), err)
+	}
+
+	file = strings.TrimPrefix(path.Clean(file), "/")
+	want, links := file, 0
+	for i := len(layers) - 1; i >= 0; i-- {
+		size, err := layers[i].Size()
+		if err != nil {
+			return nil, err
+		}
+		if size > r.layerBudget {
+			logger.Infof("Not reading /%s from image %s, since the layer size limit has been reached", file, digest.DigestStr())
+			return nil, nil
+		}
+		r.layerBudget -= size
+
+		f, err := findFile(layers[i], want)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read /%s from %s: %w", file, digest.DigestStr(), err)
+		}
+		switch {
+		case !f.found:
+			continue
+		case f.symlink != "", f.hardlink != "":
+			if links++; links > maxLinks {
+				return nil, fmt.Errorf("too many links to follow reading /%s from %s", file, digest.DigestStr())
+			}
+			if f.symlink != "" {
+				// The topmost copy of the symlink's target is used.
+				want, i = f.symlink, len(layers)
+			} else {
+				// A hard link's target is in the same layer.
+				want, i = f.hardlink, i+1
+			}
+			continue
+		case f.contents == nil:
+			return nil, nil
+		}
+		logger.Debugf("Image %s specified a seccomp profile in /%s", digest.DigestStr(), file)
+		return parseProfile(ctx, digest.DigestStr(), f.contents)
+	}
+	return nil, nil
+}
+
+// layerFile is the result of finding a file in a layer.
+type layerFile struct {
+	// found is true if the layer has the file, or removes it from the
+	// layers below if contents, symlink and hardlink are all empty.
+	found    bool
+	contents []byte
+	// symlink and hardlink are the files, relative to the root, that the
+	// file links to.
+	symlink, hardlink string
+}
+
+// findFile finds the file, relative to the root, in the layer.
+func findFile(l v1.Layer, file string) (layerFile, error) {
+	rc, err := l.Uncompressed()
+	if err != nil {
+		return layerFile{}, err
+	}
+	defer rc.Close()
+
+	removed := false
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return layerFile{found: removed}, nil
+		} else if err != nil {
+			return layerFile{}, err
+		}
+
+		entry := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		if entry != file {
+			removed = removed || removes(entry, file)
+			continue
+		}
+		switch hdr.Typeflag {
+		case tar.TypeSymlink:
+			target := hdr.Linkname
+			if !path.IsAbs(target) {
+				target = path.Join("/", path.Dir(file), target)
+			}
+			return layerFile{found: true, symlink: strings.TrimPrefix(path.Clean(target), "/")}, nil
+		case tar.TypeLink:
+			return layerFile{found: true, hardlink: strings.TrimPrefix(path.Clean("/"+hdr.Linkname), "/")}, nil
+		case tar.TypeReg:
+		default:
+			return layerFile{}, fmt.Errorf("/%s isn't a regular file", file)
+		}
+		if hdr.Size > maxProfileSize {
+			return layerFile{}, fmt.Errorf("/%s is %d bytes, more than the limit of %d", file, hdr.Size, maxProfileSize)
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return layerFile{}, err
+		}
+		return layerFile{found: true, contents: b}, nil
+	}
+}
+
+// removes returns true if the layer entry is a whiteout that removes the
+// file, or one of its directories, from the layers below.
+func removes(entry, file string) bool {
+	for p := file; p != "."; p = path.Dir(p) {
+		dir, base := path.Split(p)
+		if entry == dir+whiteoutPrefix+base {
+			return true
+		}
+		if p != file && entry == path.Join(p, whiteoutOpaque) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
+)
+
+// fakeImage describes an image to put in a fakeRegistry.
+type fakeImage struct {
+	annotation string
+	label      string
+	// layers map each file to its contents, from the bottom layer up.
+	// Contents starting with "-> " make the file a symlink to the rest.
+	layers []map[string]string
+}
+
+func (f *fakeRegistry) putImage(t *testing.T, img fakeImage) v1.Hash {
+	t.Helper()
+
+	var layers []v1.Descriptor
+	for _, files := range img.layers {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for name, contents := range files {
+			if target := strings.TrimPrefix(contents, "-> "); target != contents {
+				if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0777, Linkname: target, Typeflag: tar.TypeSymlink}); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte(contents)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+		layers = append(layers, v1.Descriptor{
+			MediaType: types.OCILayer,
+			Digest:    f.put(f.blobs, buf.Bytes()),
+			Size:      int64(buf.Len()),
+		})
+	}
+
+	cf := v1.ConfigFile{Architecture: "amd64", OS: "linux"}
+	if img.label != "" {
+		cf.Config.Labels = map[string]string{profileKey: img.label}
+	}
+	b, err := json.Marshal(cf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mf := v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config:        v1.Descriptor{MediaType: types.OCIConfigJSON, Digest: f.put(f.blobs, b), Size: int64(len(b))},
+		Layers:        layers,
+	}
+	if img.annotation != "" {
+		mf.Annotations = map[string]string{profileKey: img.annotation}
+	}
+	if b, err = json.Marshal(mf); err != nil {
+		t.Fatal(err)
+	}
+	return f.put(f.manifests, b)
+}
+
+func TestManifestProfile(t *testing.T) {
+	const (
+		errno = `{"defaultAction":"SCMP_ACT_ERRNO"}`
+		log   = `{"defaultAction":"SCMP_ACT_LOG"}`
+		file  = "/etc/seccomp/profile.json"
+	)
+
+	for _, c := range []struct {
+		desc    string
+		image   fakeImage
+		sources string
+		limit   string
+		want    v1beta1.Action
+		wantErr bool
+	}{{
+		desc:  "annotation",
+		image: fakeImage{annotation: errno},
+		want:  v1beta1.ActionErr,
+	}, {
+		desc:  "label",
+		image: fakeImage{label: errno},
+		want:  v1beta1.ActionErr,
+	}, {
+		desc:    "label before annotation",
+		image:   fakeImage{annotation: errno, label: log},
+		sources: "label,annotation",
+		want:    v1beta1.ActionLog,
+	}, {
+		desc:  "file not read by default",
+		image: fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": errno}}},
+	}, {
+		desc:    "file",
+		image:   fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": errno}, {"bin/app": "app"}}},
+		sources: "file",
+		want:    v1beta1.ActionErr,
+	}, {
+		desc:    "topmost file",
+		image:   fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": errno}, {"./etc/seccomp/profile.json": log}}},
+		sources: "file",
+		want:    v1beta1.ActionLog,
+	}, {
+		desc:    "removed file",
+		image:   fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": errno}, {"etc/seccomp/.wh.profile.json": ""}}},
+		sources: "file",
+	}, {
+		desc:    "removed directory",
+		image:   fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": errno}, {"etc/.wh.seccomp": ""}}},
+		sources: "file",
+	}, {
+		desc: "opaque directory",
+		image: fakeImage{layers: []map[string]string{
+			{"etc/seccomp/profile.json": errno},
+			{"etc/seccomp/.wh..wh..opq": ""},
+		}},
+		sources: "file",
+	}, {
+		desc: "file added to opaque directory",
+		image: fakeImage{layers: []map[string]string{
+			{"etc/seccomp/profile.json": errno},
+			{"etc/seccomp/.wh..wh..opq": "", "etc/seccomp/profile.json": log},
+		}},
+		sources: "file",
+		want:    v1beta1.ActionLog,
+	}, {
+		desc: "relative symlink",
+		image: fakeImage{layers: []map[string]string{
+			{"usr/share/app/seccomp.json": errno},
+			{"etc/seccomp/profile.json": "-> ../../usr/share/app/seccomp.json"},
+		}},
+		sources: "file",
+		want:    v1beta1.ActionErr,
+	}, {
+		desc: "absolute symlink to a file in a higher layer",
+		image: fakeImage{layers: []map[string]string{
+			{"etc/seccomp/profile.json": "-> /opt/profile.json", "opt/profile.json": errno},
+			{"opt/profile.json": log},
+		}},
+		sources: "file",
+		want:    v1beta1.ActionLog,
+	}, {
+		desc:    "symlink loop",
+		image:   fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": "-> profile.json"}}},
+		sources: "file",
+		wantErr: true,
+	}, {
+		// Images over the limit are treated as declaring no profile.
+		desc:    "layers over the limit",
+		image:   fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": errno}, {"bin/app": strings.Repeat("x", 1024)}}},
+		sources: "file",
+		limit:   "64",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			f := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}, referrers: map[string][]byte{}}
+			h := f.putImage(t, c.image)
+			s := httptest.NewServer(f)
+			defer s.Close()
+
+			data := map[string]string{"image-profile-file": file}
+			if c.sources != "" {
+				data["image-profile-sources"] = c.sources
+			}
+			if c.limit != "" {
+				data["image-layer-size-limit"] = c.limit
+			}
+			cfg, err := config.NewConfigFromMap(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := config.ToContext(context.Background(), cfg)
+
+			digest, err := name.NewDigest(strings.TrimPrefix(s.URL, "http://") + "/repo@" + h.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &registry{keychain: authn.NewMultiKeychain(), opts: []remote.Option{remote.WithContext(ctx)}, layerBudget: cfg.ImageLayerSizeLimit}
+			desc, err := remote.Get(digest, r.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.manifestProfile(ctx, digest, desc)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("manifestProfile() = %v, wantErr %t", err, c.wantErr)
+			}
+			switch {
+			case c.want == "" && got != nil:
+				t.Errorf("manifestProfile() = %+v, want nil", got.contents)
+			case c.want != "" && got == nil:
+				t.Errorf("manifestProfile() = nil, want %s", c.want)
+			case c.want != "" && got.contents.DefaultAction != c.want:
+				t.Errorf("DefaultAction = %s, want %s", got.contents.DefaultAction, c.want)
+			}
+		})
+	}
+}
+
+func TestFileProfileBudget(t *testing.T) {
+	f := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}, referrers: map[string][]byte{}}
+	img := fakeImage{layers: []map[string]string{{"etc/seccomp/profile.json": `{"defaultAction":"SCMP_ACT_ERRNO"}`}}}
+	first, second := f.putImage(t, img), f.putImage(t, fakeImage{layers: append(img.layers, map[string]string{"bin/app": "app"})})
+	s := httptest.NewServer(f)
+	defer s.Close()
+
+	ctx := context.Background()
+	r := &registry{keychain: authn.NewMultiKeychain(), opts: []remote.Option{remote.WithContext(ctx)}}
+	var descs []*remote.Descriptor
+	var digests []name.Digest
+	for _, h := range []v1.Hash{first, second} {
+		digest, err := name.NewDigest(strings.TrimPrefix(s.URL, "http://") + "/repo@" + h.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		desc, err := remote.Get(digest, r.opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		digests, descs = append(digests, digest), append(descs, desc)
+	}
+
+	// The budget is enough for the first image's layer, and one more.
+	img1, _ := descs[0].Image()
+	layers, _ := img1.Layers()
+	size, _ := layers[0].Size()
+	r.layerBudget = 2 * size
+
+	if p, err := r.fileProfile(ctx, digests[0], descs[0], "/etc/seccomp/profile.json"); err != nil || p == nil {
+		t.Fatalf("fileProfile(first) = %v, %v, want a profile", p, err)
+	}
+	// The second image's layers don't fit in what's left.
+	if p, err := r.fileProfile(ctx, digests[1], descs[1], "/etc/seccomp/profile.json"); err != nil || p != nil {
+		t.Errorf("fileProfile(second) = %v, %v, want nil", p, err)
+	}
+}
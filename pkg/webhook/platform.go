@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/logging"
+)
+
+// podArches returns the architectures the pod is pinned to by its
+// nodeSelector or required node affinity, or nil if it can run on any.
+//
+// Affinity terms are ORed, so the pod is only pinned by its affinity if
+// every term has an In expression on the architecture label.
+func podArches(ps *corev1.PodSpec) sets.String {
+	var out sets.String
+	if arch, ok := ps.NodeSelector[corev1.LabelArchStable]; ok {
+		out = sets.NewString(arch)
+	}
+
+	if a := ps.Affinity; a != nil && a.NodeAffinity != nil && a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms := a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		affinity := sets.NewString()
+		for _, t := range terms {
+			var term sets.String
+			for _, e := range t.MatchExpressions {
+				if e.Key != corev1.LabelArchStable || e.Operator != corev1.NodeSelectorOpIn {
+					continue
+				}
+				if term == nil {
+					term = sets.NewString(e.Values...)
+				} else {
+					term = term.Intersection(sets.NewString(e.Values...))
+				}
+			}
+			if term == nil {
+				affinity = nil
+				break
+			}
+			affinity = affinity.Union(term)
+		}
+		if len(terms) != 0 && affinity != nil {
+			if out == nil {
+				out = affinity
+			} else {
+				out = out.Intersection(affinity)
+			}
+		}
+	}
+
+	// A pod that can't run anywhere isn't pinned to anything.
+	if out.Len() == 0 {
+		return nil
+	}
+	return out
+}
+
+// indexProfile returns the profile an image index declares for the
+// platforms with the given architectures, or for every platform if arches
+// is nil, merging them if they differ.
+//
+// Profiles declared for the index itself apply to every platform.
+// Otherwise, if any of the platforms declares no profile, nil is returned,
+// since the pod might run on that platform.
+func (r *registry) indexProfile(ctx context.Context, digest name.Digest, desc *remote.Descriptor, arches sets.String) (*declaredProfile, error) {
+	logger := logging.FromContext(ctx)
+
+	if p, err := r.manifestProfile(ctx, digest, desc); p != nil || err != nil {
+		return p, err
+	}
+	im, err := v1.ParseIndexManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse index: %w", err)
+	}
+
+	var profiles []*declaredProfile
+	for _, m := range im.Manifests {
+		// Skip nested indexes, and artifacts such as attestations, which
+		// have no platform or an "unknown" one.
+		if !m.MediaType.IsImage() || m.Platform == nil || m.Platform.OS != "linux" {
+			continue
+		}
+		if arches != nil && !arches.Has(m.Platform.Architecture) {
+			continue
+		}
+		ref := digest.Context().Digest(m.Digest.String())
+		child, err := remoteResolveDigest(ref, r.opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get manifest for %s: %w", m.Platform, err)
+		}
+		p, err := r.manifestProfile(ctx, ref, child)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			logger.Infof("Image %s specified no seccomp profile for %s", desc.Digest.String(), m.Platform)
+			return nil, nil
+		}
+		profiles = append(profiles, p)
+	}
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	return unionProfile(profiles)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPodArches(t *testing.T) {
+	in := func(key string, values ...string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpIn, Values: values}
+	}
+	affinity := func(terms ...[]corev1.NodeSelectorRequirement) *corev1.Affinity {
+		a := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{},
+		}}
+		for _, t := range terms {
+			a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = append(
+				a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms,
+				corev1.NodeSelectorTerm{MatchExpressions: t})
+		}
+		return a
+	}
+
+	for _, c := range []struct {
+		desc string
+		in   corev1.PodSpec
+		want []string
+	}{{
+		desc: "unpinned",
+	}, {
+		desc: "node selector",
+		in:   corev1.PodSpec{NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"}},
+		want: []string{"arm64"},
+	}, {
+		desc: "affinity",
+		in: corev1.PodSpec{Affinity: affinity(
+			[]corev1.NodeSelectorRequirement{in(corev1.LabelArchStable, "amd64")},
+			[]corev1.NodeSelectorRequirement{in(corev1.LabelArchStable, "arm64", "s390x"), in(corev1.LabelArchStable, "arm64")},
+		)},
+		want: []string{"amd64", "arm64"},
+	}, {
+		desc: "affinity term without architecture",
+		in: corev1.PodSpec{Affinity: affinity(
+			[]corev1.NodeSelectorRequirement{in(corev1.LabelArchStable, "amd64")},
+			[]corev1.NodeSelectorRequirement{in(corev1.LabelOSStable, "linux")},
+		)},
+	}, {
+		desc: "node selector and affinity",
+		in: corev1.PodSpec{
+			NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"},
+			Affinity:     affinity([]corev1.NodeSelectorRequirement{in(corev1.LabelArchStable, "amd64", "arm64")}),
+		},
+		want: []string{"arm64"},
+	}, {
+		desc: "unschedulable",
+		in: corev1.PodSpec{
+			NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"},
+			Affinity:     affinity([]corev1.NodeSelectorRequirement{in(corev1.LabelArchStable, "amd64")}),
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			got := podArches(&c.in)
+			if got == nil {
+				if c.want != nil {
+					t.Fatalf("podArches() = nil, want %v", c.want)
+				}
+				return
+			}
+			if d := cmp.Diff(c.want, got.List()); d != "" {
+				t.Errorf("Diff (-want,+got): %s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"knative.dev/pkg/logging"
+)
+
+// profileArtifactType is the artifactType of artifacts that attach a
+// seccomp profile to the image they refer to. The profile is the
+// artifact's only layer.
+const profileArtifactType = "application/vnd.dev.imjasonh.seccomp.profile.v1+json"
+
+// maxProfileSize limits the size of profiles read from artifacts, and of
+// the indexes listing them.
+const maxProfileSize = 1 << 20
+
+// createdAnnotation is the OCI annotation for when an artifact was
+// created, used to pick the latest of several profiles.
+const createdAnnotation = "org.opencontainers.image.created"
+
+// referrer describes a manifest that refers to an image. It's declared here
+// since go-containerregistry's v1.Descriptor doesn't have artifactType.
+type referrer struct {
+	MediaType    types.MediaType   `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       v1.Hash           `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// referrers is the index listing the manifests that refer to an image,
+// returned by the referrers API or pushed to the referrers tag.
+type referrers struct {
+	Manifests []referrer `json:"manifests"`
+}
+
+// referrersProfile returns the profile attached to the image by an artifact
+// that refers to it, or nil if none is attached.
+func (r *registry) referrersProfile(ctx context.Context, digest name.Digest) (*declaredProfile, error) {
+	logger := logging.FromContext(ctx)
+
+	refs, err := r.referrers(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	var found *referrer
+	for i, ref := range refs.Manifests {
+		if ref.ArtifactType != profileArtifactType {
+			continue
+		}
+		if found == nil || ref.Annotations[createdAnnotation] > found.Annotations[createdAnnotation] {
+			found = &refs.Manifests[i]
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	logger.Debugf("Image %s has a seccomp profile attached by %s", digest.DigestStr(), found.Digest.String())
+
+	desc, err := remoteResolveDigest(digest.Context().Digest(found.Digest.String()), r.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get artifact %s: %w", found.Digest.String(), err)
+	}
+	var mf struct {
+		Layers []v1.Descriptor `json:"layers"`
+	}
+	if err := json.Unmarshal(desc.Manifest, &mf); err != nil {
+		return nil, fmt.Errorf("unable to parse artifact %s: %w", found.Digest.String(), err)
+	}
+	if len(mf.Layers) != 1 {
+		return nil, fmt.Errorf("artifact %s has %d layers, want 1", found.Digest.String(), len(mf.Layers))
+	}
+	if mf.Layers[0].Size > maxProfileSize {
+		return nil, fmt.Errorf("artifact %s has a %d byte profile, more than the limit of %d", found.Digest.String(), mf.Layers[0].Size, maxProfileSize)
+	}
+
+	l, err := remote.Layer(digest.Context().Digest(mf.Layers[0].Digest.String()), r.opts...)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := l.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get profile from artifact %s: %w", found.Digest.String(), err)
+	}
+	defer rc.Close()
+	b, err := io.ReadAll(io.LimitReader(rc, maxProfileSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read profile from artifact %s: %w", found.Digest.String(), err)
+	}
+	return parseProfile(ctx, digest.DigestStr(), b)
+}
+
+// referrers lists the manifests that refer to the image, from the OCI 1.1
+// referrers API or, if the registry doesn't support it, from the referrers
+// tag, named for the image's digest.
+//
+// The vendored go-containerregistry predates the referrers API, so it's
+// called directly, with the same keychain as everything else.
+func (r *registry) referrers(ctx context.Context, digest name.Digest) (*referrers, error) {
+	repo := digest.Context()
+	auth, err := r.keychain.Resolve(repo)
+	if err != nil {
+		return nil, err
+	}
+	rt, err := transport.NewWithContext(ctx, repo.Registry, auth, remote.DefaultTransport, []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return nil, err
+	}
+	u := url.URL{
+		Scheme:   repo.Registry.Scheme(),
+		Host:     repo.RegistryStr(),
+		Path:     fmt.Sprintf("/v2/%s/referrers/%s", repo.RepositoryStr(), digest.DigestStr()),
+		RawQuery: url.Values{"artifactType": {profileArtifactType}}.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", string(types.OCIImageIndex))
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var b []byte
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if b, err = io.ReadAll(io.LimitReader(resp.Body, maxProfileSize)); err != nil {
+			return nil, err
+		}
+	case http.StatusNotFound:
+		// Registries supporting the API return an empty index for images
+		// with no referrers, so fall back to the tag.
+		tag := repo.Tag(strings.Replace(digest.DigestStr(), ":", "-", 1))
+		desc, err := remoteResolveDigest(tag, r.opts...)
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return &referrers{}, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to get referrers tag %s: %w", tag, err)
+		}
+		b = desc.Manifest
+	default:
+		return nil, transport.CheckError(resp, http.StatusOK)
+	}
+
+	var refs referrers
+	if err := json.Unmarshal(b, &refs); err != nil {
+		return nil, fmt.Errorf("unable to parse referrers of %s: %w", digest.DigestStr(), err)
+	}
+	return &refs, nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+)
+
+// fakeRegistry serves manifests and blobs by digest or tag, and referrers
+// if the registry supports the referrers API.
+type fakeRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	referrers map[string][]byte // By subject digest, or nil if unsupported.
+}
+
+func (f *fakeRegistry) put(m map[string][]byte, b []byte) v1.Hash {
+	h, _, _ := v1.SHA256(strings.NewReader(string(b)))
+	m[h.String()] = b
+	return h
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/v2/" {
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v2/repo/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	switch parts[0] {
+	case "manifests":
+		b, ok := f.manifests[parts[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		var mf struct {
+			MediaType types.MediaType `json:"mediaType"`
+		}
+		json.Unmarshal(b, &mf)
+		w.Header().Set("Content-Type", string(mf.MediaType))
+		w.Write(b)
+	case "blobs":
+		b, ok := f.blobs[parts[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(b)
+	case "referrers":
+		if f.referrers == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", string(types.OCIImageIndex))
+		if b, ok := f.referrers[parts[1]]; ok {
+			w.Write(b)
+		} else {
+			fmt.Fprintf(w, `{"schemaVersion":2,"mediaType":%q,"manifests":[]}`, types.OCIImageIndex)
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestReferrersProfile(t *testing.T) {
+	const profile = `{"defaultAction":"SCMP_ACT_ERRNO","syscalls":[{"names":["read"],"action":"SCMP_ACT_ALLOW"}]}`
+	subject := v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("a", 64)}
+
+	// artifact puts an artifact of the type attaching the profile, and
+	// returns the index listing it.
+	artifact := func(f *fakeRegistry, artifactType string) []byte {
+		blob := f.put(f.blobs, []byte(profile))
+		mf, _ := json.Marshal(map[string]interface{}{
+			"schemaVersion": 2,
+			"mediaType":     types.OCIManifestSchema1,
+			"artifactType":  artifactType,
+			"config":        v1.Descriptor{MediaType: "application/vnd.oci.empty.v1+json", Size: 2},
+			"layers":        []v1.Descriptor{{MediaType: "application/json", Digest: blob, Size: int64(len(profile))}},
+			"subject":       v1.Descriptor{MediaType: types.OCIManifestSchema1, Digest: subject},
+		})
+		digest := f.put(f.manifests, mf)
+		idx, _ := json.Marshal(map[string]interface{}{
+			"schemaVersion": 2,
+			"mediaType":     types.OCIImageIndex,
+			"manifests": []referrer{{
+				MediaType:    types.OCIManifestSchema1,
+				ArtifactType: artifactType,
+				Digest:       digest,
+				Size:         int64(len(mf)),
+			}},
+		})
+		return idx
+	}
+	tag := strings.Replace(subject.String(), ":", "-", 1)
+
+	for _, c := range []struct {
+		desc  string
+		setup func(f *fakeRegistry)
+		want  bool
+	}{{
+		desc: "referrers API",
+		setup: func(f *fakeRegistry) {
+			f.referrers = map[string][]byte{subject.String(): artifact(f, profileArtifactType)}
+		},
+		want: true,
+	}, {
+		desc: "referrers tag",
+		setup: func(f *fakeRegistry) {
+			f.manifests[tag] = artifact(f, profileArtifactType)
+		},
+		want: true,
+	}, {
+		desc: "other artifact type",
+		setup: func(f *fakeRegistry) {
+			f.referrers = map[string][]byte{subject.String(): artifact(f, "application/vnd.example.sbom")}
+		},
+	}, {
+		desc:  "no referrers",
+		setup: func(f *fakeRegistry) { f.referrers = map[string][]byte{} },
+	}, {
+		desc:  "no referrers tag",
+		setup: func(f *fakeRegistry) {},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			f := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+			c.setup(f)
+			s := httptest.NewServer(f)
+			defer s.Close()
+
+			ctx := context.Background()
+			digest, err := name.NewDigest(strings.TrimPrefix(s.URL, "http://") + "/repo@" + subject.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := &registry{keychain: authn.NewMultiKeychain(), opts: []remote.Option{remote.WithContext(ctx)}}
+			got, err := r.referrersProfile(ctx, digest)
+			if err != nil {
+				t.Fatalf("referrersProfile: %v", err)
+			}
+			if !c.want {
+				if got != nil {
+					t.Fatalf("referrersProfile() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("referrersProfile() = nil")
+			}
+			if got.name != sha(profile) {
+				t.Errorf("name = %q, want %q", got.name, sha(profile))
+			}
+			if got.contents.DefaultAction != v1beta1.ActionErr || len(got.contents.Syscalls) != 1 {
+				t.Errorf("contents = %+v", got.contents)
+			}
+		})
+	}
+}
@@ -18,18 +18,21 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
-	"errors"
 	"fmt"
 
+	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/kubernetes"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
-	v1alpha1client "github.com/imjasonh/seccomp-profile/pkg/apis/injection/client"
+	seccompclient "github.com/imjasonh/seccomp-profile/pkg/apis/injection/client"
 	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1alpha1"
+	"github.com/imjasonh/seccomp-profile/pkg/apis/seccomp/v1beta1"
+	"github.com/imjasonh/seccomp-profile/pkg/config"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -62,7 +65,7 @@ func (v *Validator) ResolvePodSpecable(ctx context.Context, wp *duckv1.WithPod)
 	for _, s := range wp.Spec.Template.Spec.ImagePullSecrets {
 		imagePullSecrets = append(imagePullSecrets, s.Name)
 	}
-	v.resolvePodSpec(ctx, &wp.Spec.Template.Spec, kubernetes.Options{
+	v.resolvePodSpec(ctx, &wp.Spec.Template.Spec, false, kubernetes.Options{
 		Namespace:          getNamespace(ctx, wp.Namespace),
 		ServiceAccountName: wp.Spec.Template.Spec.ServiceAccountName,
 		ImagePullSecrets:   imagePullSecrets,
@@ -80,7 +83,7 @@ func (v *Validator) ResolvePod(ctx context.Context, p *duckv1.Pod) {
 	for _, s := range p.Spec.ImagePullSecrets {
 		imagePullSecrets = append(imagePullSecrets, s.Name)
 	}
-	v.resolvePodSpec(ctx, &p.Spec, kubernetes.Options{
+	v.resolvePodSpec(ctx, &p.Spec, !apis.IsInCreate(ctx), kubernetes.Options{
 		Namespace:          getNamespace(ctx, p.Namespace),
 		ServiceAccountName: p.Spec.ServiceAccountName,
 		ImagePullSecrets:   imagePullSecrets,
@@ -99,7 +102,7 @@ func (v *Validator) ResolveCronJob(ctx context.Context, c *duckv1.CronJob) {
 	for _, s := range c.Spec.JobTemplate.Spec.Template.Spec.ImagePullSecrets {
 		imagePullSecrets = append(imagePullSecrets, s.Name)
 	}
-	v.resolvePodSpec(ctx, &c.Spec.JobTemplate.Spec.Template.Spec, kubernetes.Options{
+	v.resolvePodSpec(ctx, &c.Spec.JobTemplate.Spec.Template.Spec, false, kubernetes.Options{
 		Namespace:          getNamespace(ctx, c.Namespace),
 		ServiceAccountName: c.Spec.JobTemplate.Spec.Template.Spec.ServiceAccountName,
 		ImagePullSecrets:   imagePullSecrets,
@@ -109,7 +112,11 @@ func (v *Validator) ResolveCronJob(ctx context.Context, c *duckv1.CronJob) {
 // For testing
 var remoteResolveDigest = remote.Get
 
-func (v *Validator) resolvePodSpec(ctx context.Context, ps *corev1.PodSpec, opt kubernetes.Options) {
+// resolvePodSpec resolves the images of the pod spec's containers to
+// digests and applies the profiles they declare. created is true for the
+// spec of an existing pod, whose securityContexts can't be changed, so only
+// its images are resolved.
+func (v *Validator) resolvePodSpec(ctx context.Context, ps *corev1.PodSpec, created bool, opt kubernetes.Options) {
 	logger := logging.FromContext(ctx)
 
 	client := kubeclient.Get(ctx)
@@ -118,127 +125,284 @@ func (v *Validator) resolvePodSpec(ctx context.Context, ps *corev1.PodSpec, opt
 		logger.Warnf("Unable to build keychain: %v", err)
 		return
 	}
+	opts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(kc),
+	}
+	// The layer budget is shared by every image of the pod, so that one
+	// with many images doesn't hold up admission either.
+	reg := &registry{keychain: kc, opts: opts, layerBudget: config.FromContextOrDefaults(ctx).ImageLayerSizeLimit}
 
-	var desc *remote.Descriptor
+	// resolve resolves the image's tag to a digest, returning the image by
+	// digest and its descriptor, or the image unchanged and nil if it can't
+	// be resolved.
+	resolve := func(image string) (string, *remote.Descriptor) {
+		ref, err := name.ParseReference(image)
+		if err != nil {
+			logger.Debugf("Unable to parse reference: %v", err)
+			return image, nil
+		}
 
-	resolveContainers := func(cs []corev1.Container) {
-		for i, c := range cs {
-			ref, err := name.ParseReference(c.Image)
+		// If we are in the context of a mutating webhook, then resolve the tag to a digest.
+		switch {
+		case apis.IsInCreate(ctx), apis.IsInUpdate(ctx):
+			desc, err := remoteResolveDigest(ref, opts...)
 			if err != nil {
-				logger.Debugf("Unable to parse reference: %v", err)
-				continue
-			}
-
-			// If we are in the context of a mutating webhook, then resolve the tag to a digest.
-			switch {
-			case apis.IsInCreate(ctx), apis.IsInUpdate(ctx):
-				desc, err = remoteResolveDigest(ref,
-					remote.WithContext(ctx),
-					remote.WithAuthFromKeychain(kc),
-				)
-				if err != nil {
-					logger.Debugf("Unable to resolve digest %q: %v", ref.String(), err)
-					continue
-				}
-				cs[i].Image = ref.Context().Digest(desc.Digest.String()).String()
+				logger.Debugf("Unable to resolve digest %q: %v", ref.String(), err)
+				return image, nil
 			}
+			return ref.Context().Digest(desc.Digest.String()).String(), desc
 		}
+		return image, nil
 	}
 
-	resolveEphemeralContainers := func(cs []corev1.EphemeralContainer) {
-		for i, c := range cs {
-			ref, err := name.ParseReference(c.Image)
-			if err != nil {
-				logger.Debugf("Unable to parse reference: %v", err)
-				continue
-			}
+	// A seccompProfile set for the whole pod is left alone, and applies to
+	// every container that doesn't set its own.
+	podProfile := ps.SecurityContext != nil && ps.SecurityContext.SeccompProfile != nil
+	skip := created || podProfile
+
+	// Only the platforms of image indexes that the pod can run on are
+	// considered.
+	arches := podArches(ps)
+
+	// Collect the profiles declared by the images of containers that don't
+	// set their own seccompProfile.
+	var declared []containerProfile
+	undeclared := false
+	collect := func(container, image string, desc *remote.Descriptor, sc **corev1.SecurityContext) {
+		if skip || (*sc != nil && (*sc).SeccompProfile != nil) {
+			return
+		}
+		if desc == nil {
+			undeclared = true
+			return
+		}
+		p, err := reg.imageProfile(ctx, image, desc, arches)
+		if err != nil {
+			logger.Errorf("Error reading profile for container %q: %v", container, err)
+		}
+		if p == nil {
+			undeclared = true
+			return
+		}
+		declared = append(declared, containerProfile{container: container, securityContext: sc, profile: p})
+	}
+	for _, cs := range [][]corev1.Container{ps.InitContainers, ps.Containers} {
+		for i := range cs {
+			var desc *remote.Descriptor
+			cs[i].Image, desc = resolve(cs[i].Image)
+			collect(cs[i].Name, cs[i].Image, desc, &cs[i].SecurityContext)
+		}
+	}
+	for i := range ps.EphemeralContainers {
+		c := &ps.EphemeralContainers[i]
+		var desc *remote.Descriptor
+		c.Image, desc = resolve(c.Image)
+		collect(c.Name, c.Image, desc, &c.SecurityContext)
+	}
+	if created || len(declared) == 0 {
+		return
+	}
 
-			// If we are in the context of a mutating webhook, then resolve the tag to a digest.
-			switch {
-			case apis.IsInCreate(ctx), apis.IsInUpdate(ctx):
-				desc, err = remoteResolveDigest(ref,
-					remote.WithContext(ctx),
-					remote.WithAuthFromKeychain(kc),
-				)
-				if err != nil {
-					logger.Debugf("Unable to resolve digest %q: %v", ref.String(), err)
-					continue
-				}
-				cs[i].Image = ref.Context().Digest(desc.Digest.String()).String()
+	// The union applies to every container without its own seccompProfile,
+	// so it's only used if each of them has an image that declares one.
+	if config.FromContextOrDefaults(ctx).ImageProfileScope == config.ScopePod && !undeclared {
+		profile, err := createUnionProfile(ctx, declared)
+		if err == nil {
+			if ps.SecurityContext == nil {
+				ps.SecurityContext = &corev1.PodSecurityContext{}
 			}
+			ps.SecurityContext.SeccompProfile = profile
+			logger.Info("Updated pod with union SeccompProfile")
+			return
 		}
+		logger.Errorf("Error creating union profile, setting containers' profiles instead: %v", err)
 	}
 
-	resolveContainers(ps.InitContainers)
-	resolveContainers(ps.Containers)
-	resolveEphemeralContainers(ps.EphemeralContainers)
-
-	// If there's only one container, and there isn't already a seccompProfile specified, try to extract the image's seccomp policy.
-	// TODO: If multiple images each specify a seccomp profile, generate a policy that is the union of those policies.
-	if len(ps.InitContainers) == 0 &&
-		len(ps.EphemeralContainers) == 0 &&
-		len(ps.Containers) == 1 &&
-		(ps.SecurityContext == nil || ps.SecurityContext.SeccompProfile == nil) {
-		if err := mutatePodSpec(ctx, desc, ps); err != nil {
-			logger.Errorf("Error mutating PodSpec: %v", err)
+	for _, d := range declared {
+		profile, err := createProfile(ctx, d.profile.name, d.profile.contents)
+		if err != nil {
+			logger.Errorf("Error creating profile for container %q: %v", d.container, err)
+			continue
+		}
+		if *d.securityContext == nil {
+			*d.securityContext = &corev1.SecurityContext{}
 		}
+		(*d.securityContext).SeccompProfile = profile
+		logger.Infof("Updated container %q with SeccompProfile", d.container)
 	}
 }
 
-func mutatePodSpec(ctx context.Context, desc *remote.Descriptor, ps *corev1.PodSpec) error {
-	logger := logging.FromContext(ctx)
+// containerProfile is the profile declared by a container's image.
+type containerProfile struct {
+	container       string
+	securityContext **corev1.SecurityContext
+	profile         *declaredProfile
+}
 
-	if desc == nil {
-		return errors.New("BUG: Expected descriptor to already be populated")
-	}
+// declaredProfile is a profile an image declares, and the name of the
+// SeccompProfile created for it.
+type declaredProfile struct {
+	name     string
+	contents *v1beta1.SeccompProfileJSON
+}
 
-	b, err := desc.RawManifest()
+// profileKey is the manifest annotation, or config label, holding the
+// profile an image declares.
+const profileKey = "seccomp.imjasonh.dev/profile"
+
+// registry reads the profiles images declare from their registries.
+type registry struct {
+	keychain authn.Keychain
+	opts     []remote.Option
+	// layerBudget is how many more compressed bytes of layers can be read
+	// to find profiles in images' filesystems.
+	layerBudget int64
+}
+
+// imageProfile returns the profile the image, by digest, declares, or nil
+// if it declares none. For an image index, the profiles of the platforms
+// with the given architectures, or of every platform if arches is nil, are
+// merged.
+func (r *registry) imageProfile(ctx context.Context, image string, desc *remote.Descriptor, arches sets.String) (*declaredProfile, error) {
+	digest, err := name.NewDigest(image)
 	if err != nil {
-		return fmt.Errorf("unable to get raw manifest: %w", err)
+		return nil, err
+	}
+	if desc.MediaType.IsIndex() {
+		return r.indexProfile(ctx, digest, desc, arches)
 	}
+	return r.manifestProfile(ctx, digest, desc)
+}
+
+// manifestProfile returns the profile declared for the image or index by
+// the first of the configured sources that declares one, or nil if none
+// does. Only images have labels and filesystems.
+func (r *registry) manifestProfile(ctx context.Context, digest name.Digest, desc *remote.Descriptor) (*declaredProfile, error) {
+	logger := logging.FromContext(ctx)
+	cfg := config.FromContextOrDefaults(ctx)
+
+	for _, src := range cfg.ImageProfileSources {
+		var p *declaredProfile
+		var err error
+		switch src {
+		case config.SourceAnnotation:
+			p, err = annotationProfile(ctx, digest, desc.Manifest)
+		case config.SourceReferrers:
+			p, err = r.referrersProfile(ctx, digest)
+		case config.SourceLabel:
+			if desc.MediaType.IsImage() {
+				p, err = labelProfile(ctx, digest, desc)
+			}
+		case config.SourceFile:
+			if desc.MediaType.IsImage() {
+				p, err = r.fileProfile(ctx, digest, desc, cfg.ImageProfileFile)
+			}
+		}
+		if err != nil || p != nil {
+			return p, err
+		}
+	}
+	logger.Infof("Image %s specified no seccomp profile", digest.DigestStr())
+	return nil, nil
+}
+
+// annotationProfile returns the profile declared by the manifest's
+// annotation, or nil if it has none.
+func annotationProfile(ctx context.Context, digest name.Digest, b []byte) (*declaredProfile, error) {
 	var mf struct {
 		Annotations map[string]string `json:"annotations"`
 	}
 	if err := json.Unmarshal(b, &mf); err != nil {
-		return fmt.Errorf("unable to parse manifest: %w", err)
+		return nil, fmt.Errorf("unable to parse manifest: %w", err)
 	}
-	v, ok := mf.Annotations["seccomp.imjasonh.dev/profile"]
+	v, ok := mf.Annotations[profileKey]
 	if !ok {
-		logger.Infof("Image %s specified no seccomp profile", desc.Digest.String())
-		return nil
+		return nil, nil
 	}
-	logger.Infof("!!! Image %s specified a seccomp profile!", desc.Digest.String())
+	logging.FromContext(ctx).Infof("!!! Image %s specified a seccomp profile!", digest.DigestStr())
+	return parseProfile(ctx, digest.DigestStr(), []byte(v))
+}
+
+// parseProfile parses a profile declared by an image.
+func parseProfile(ctx context.Context, digest string, b []byte) (*declaredProfile, error) {
+	// Image profiles are in the runtime's format, which still allows .name,
+	// so parse them as v1alpha1 and convert.
+	var ap v1alpha1.SeccompProfileJSON
+	if err := json.Unmarshal(b, &ap); err != nil {
+		return nil, fmt.Errorf("image %s specified unparseable seccomp profile", digest)
+	}
+	var p v1beta1.SeccompProfileJSON
+	ap.ConvertTo(ctx, &p)
+	return &declaredProfile{name: sha(string(b)), contents: &p}, nil
+}
 
-	var p v1alpha1.SeccompProfileJSON
-	if err := json.Unmarshal([]byte(v), &p); err != nil {
-		return fmt.Errorf("image %s specified unparseable seccomp profile", desc.Digest.String())
+// createUnionProfile creates a SeccompProfile for the union of the
+// containers' profiles, named by the hash of its contents, and returns the
+// seccompProfile that references it.
+func createUnionProfile(ctx context.Context, declared []containerProfile) (*corev1.SeccompProfile, error) {
+	profiles := make([]*declaredProfile, 0, len(declared))
+	for _, d := range declared {
+		profiles = append(profiles, d.profile)
 	}
-	name := sha(v)
-	if _, err := v1alpha1client.Get(ctx).SeccompV1alpha1().SeccompProfiles().Create(ctx, &v1alpha1.SeccompProfile{
+	union, err := unionProfile(profiles)
+	if err != nil {
+		return nil, err
+	}
+	return createProfile(ctx, union.name, union.contents)
+}
+
+// unionProfile returns the union of the profiles, named by the hash of its
+// contents. Profiles declared more than once are only included once, and a
+// single profile is returned unchanged.
+func unionProfile(profiles []*declaredProfile) (*declaredProfile, error) {
+	seen := sets.NewString()
+	contents := make([]*v1beta1.SeccompProfileJSON, 0, len(profiles))
+	for _, p := range profiles {
+		if !seen.Has(p.name) {
+			seen.Insert(p.name)
+			contents = append(contents, p.contents)
+		}
+	}
+	if len(contents) == 1 {
+		return profiles[0], nil
+	}
+	union, err := v1beta1.Union(contents...)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(union)
+	if err != nil {
+		return nil, err
+	}
+	return &declaredProfile{name: sha(string(b)), contents: union}, nil
+}
+
+// createProfile creates a SeccompProfile with the contents, if it doesn't
+// already exist, and returns the seccompProfile that references it.
+func createProfile(ctx context.Context, name string, contents *v1beta1.SeccompProfileJSON) (*corev1.SeccompProfile, error) {
+	logger := logging.FromContext(ctx)
+
+	if _, err := seccompclient.Get(ctx).SeccompV1beta1().SeccompProfiles().Create(ctx, &v1beta1.SeccompProfile{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
 		},
-		Spec: v1alpha1.SeccompProfileSpec{
-			Contents: &p,
+		Spec: v1beta1.SeccompProfileSpec{
+			Contents: contents,
 		},
 	}, metav1.CreateOptions{}); k8serrors.IsAlreadyExists(err) {
 		// Ignore.
 		logger.Infof("SeccompProfile %q already exists", name)
 	} else if err != nil {
-		return fmt.Errorf("error creating SeccompProfile %q for image %s: %w", name, desc.Digest.String(), err)
+		return nil, fmt.Errorf("error creating SeccompProfile %q: %w", name, err)
 	} else {
 		logger.Infof("Created SeccompProfile %q", name)
 	}
 
-	if ps.SecurityContext == nil {
-		ps.SecurityContext = &corev1.PodSecurityContext{}
-	}
-	ps.SecurityContext.SeccompProfile = &corev1.SeccompProfile{
+	return &corev1.SeccompProfile{
 		Type:             corev1.SeccompProfileTypeLocalhost,
-		LocalhostProfile: pointer.String(fmt.Sprintf("profiles/%s.json", name)),
-	}
-	logger.Info("Updated PodSpec with SeccompProfile")
-	return nil
+		LocalhostProfile: pointer.String(config.FromContextOrDefaults(ctx).LocalhostProfile("", name)),
+	}, nil
 }
 
 func sha(s string) string {
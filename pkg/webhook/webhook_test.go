@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	fakeseccompclient "github.com/imjasonh/seccomp-profile/pkg/apis/injection/client/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+)
+
+func TestResolvePod(t *testing.T) {
+	f := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}, referrers: map[string][]byte{}}
+	h := f.putImage(t, fakeImage{annotation: `{"defaultAction":"SCMP_ACT_ERRNO"}`})
+	s := httptest.NewServer(f)
+	defer s.Close()
+	image := strings.TrimPrefix(s.URL, "http://") + "/repo@" + h.String()
+
+	for _, c := range []struct {
+		desc string
+		with func(context.Context, *duckv1.Pod) context.Context
+		want bool
+	}{{
+		desc: "create",
+		with: func(ctx context.Context, _ *duckv1.Pod) context.Context { return apis.WithinCreate(ctx) },
+		want: true,
+	}, {
+		// The securityContexts of existing pods can't be changed, so
+		// setting them would make every update fail.
+		desc: "update",
+		with: func(ctx context.Context, p *duckv1.Pod) context.Context { return apis.WithinUpdate(ctx, p.DeepCopy()) },
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx, _ := fakekubeclient.With(context.Background())
+			ctx, seccomp := fakeseccompclient.With(ctx)
+			pod := &duckv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+			}
+			ctx = c.with(ctx, pod)
+
+			NewValidator(ctx).ResolvePod(ctx, pod)
+
+			sc := pod.Spec.Containers[0].SecurityContext
+			if got := sc != nil && sc.SeccompProfile != nil; got != c.want {
+				t.Errorf("container seccompProfile set = %t, want %t", got, c.want)
+			}
+			profiles, err := seccomp.SeccompV1beta1().SeccompProfiles().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(profiles.Items) != 0; got != c.want {
+				t.Errorf("SeccompProfile created = %t, want %t", got, c.want)
+			}
+		})
+	}
+}